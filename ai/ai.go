@@ -1,8 +1,11 @@
 package ai
 
 import (
+	"context"
+	"fmt"
 	"math"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/eddiefleurent/azul-ai/game"
@@ -14,6 +17,21 @@ type Player interface {
 	Name() string
 }
 
+// ContextPlayer is a Player that can be told to stop thinking
+// Implementations return their best move so far once ctx is done
+type ContextPlayer interface {
+	Player
+	ChooseMoveContext(ctx context.Context, g *game.Game, moves []game.Move) game.Move
+}
+
+// ChooseMove asks p for a move, passing ctx along if p supports cancellation
+func ChooseMove(ctx context.Context, p Player, g *game.Game, moves []game.Move) game.Move {
+	if cp, ok := p.(ContextPlayer); ok {
+		return cp.ChooseMoveContext(ctx, g, moves)
+	}
+	return p.ChooseMove(g, moves)
+}
+
 // Difficulty levels
 type Difficulty int
 
@@ -21,22 +39,154 @@ const (
 	Easy   Difficulty = iota // Random moves
 	Medium                   // Basic heuristics
 	Hard                     // Minimax with pruning
+	MCTS                     // Monte Carlo Tree Search (UCT)
 )
 
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "easy"
+	case Medium:
+		return "medium"
+	case Hard:
+		return "hard"
+	case MCTS:
+		return "mcts"
+	default:
+		return "unknown"
+	}
+}
+
+// DifficultyFromString parses a difficulty level from user input
+func DifficultyFromString(s string) (Difficulty, bool) {
+	switch s {
+	case "easy", "Easy":
+		return Easy, true
+	case "medium", "Medium":
+		return Medium, true
+	case "hard", "Hard":
+		return Hard, true
+	case "mcts", "MCTS":
+		return MCTS, true
+	default:
+		return Medium, false
+	}
+}
+
+// SearchMode selects how the Hard AI models its opponents
+type SearchMode int
+
+const (
+	SearchAuto     SearchMode = iota // Paranoid for 2 players, max-n for 3-4
+	SearchParanoid                   // Alpha-beta: every opponent minimizes our evaluation
+	SearchMaxN                       // Every seat maximizes its own evaluation
+)
+
+func (m SearchMode) String() string {
+	switch m {
+	case SearchAuto:
+		return "auto"
+	case SearchParanoid:
+		return "paranoid"
+	case SearchMaxN:
+		return "maxn"
+	default:
+		return "unknown"
+	}
+}
+
+// SearchModeFromString parses a search mode from user input
+func SearchModeFromString(s string) (SearchMode, bool) {
+	switch s {
+	case "auto", "":
+		return SearchAuto, true
+	case "paranoid", "minimax":
+		return SearchParanoid, true
+	case "maxn", "max-n":
+		return SearchMaxN, true
+	default:
+		return SearchAuto, false
+	}
+}
+
 // AIPlayer implements an AI opponent
 type AIPlayer struct {
 	difficulty Difficulty
 	playerIdx  int
 	rng        *rand.Rand
+	mcts       MCTSConfig
+	search     SearchMode
+	thinkTime  time.Duration
+	ttSize     int
+	tt         *TranspositionTable
+	weights    EvalWeights
+}
+
+// Option customizes an AI player
+type Option func(*AIPlayer)
+
+// WithSeed seeds the AI's random choices (for reproducible games)
+func WithSeed(seed int64) Option {
+	return func(ai *AIPlayer) {
+		ai.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithMCTS sets the search budget and policy of the MCTS difficulty
+func WithMCTS(cfg MCTSConfig) Option {
+	return func(ai *AIPlayer) {
+		ai.mcts = cfg
+	}
+}
+
+// WithSearchMode sets how the Hard AI models its opponents
+func WithSearchMode(mode SearchMode) Option {
+	return func(ai *AIPlayer) {
+		ai.search = mode
+	}
+}
+
+// WithThinkTime gives the Hard AI a wall-clock budget per move
+// The search then deepens iteratively until the budget runs out, instead of
+// using a fixed depth
+func WithThinkTime(d time.Duration) Option {
+	return func(ai *AIPlayer) {
+		ai.thinkTime = d
+	}
+}
+
+// WithTranspositionTable sets how many positions the Hard AI's alpha-beta
+// search caches; 0 disables the transposition table
+func WithTranspositionTable(size int) Option {
+	return func(ai *AIPlayer) {
+		ai.ttSize = size
+	}
+}
+
+// WithWeights replaces the evaluation weights of the difficulty's preset
+func WithWeights(w EvalWeights) Option {
+	return func(ai *AIPlayer) {
+		ai.weights = w
+	}
 }
 
 // NewAIPlayer creates a new AI player
-func NewAIPlayer(difficulty Difficulty, playerIdx int) *AIPlayer {
-	return &AIPlayer{
+func NewAIPlayer(difficulty Difficulty, playerIdx int, opts ...Option) *AIPlayer {
+	ai := &AIPlayer{
 		difficulty: difficulty,
 		playerIdx:  playerIdx,
 		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		mcts:       DefaultMCTSConfig(),
+		ttSize:     DefaultTTSize,
+		weights:    DefaultWeights(),
+	}
+	for _, opt := range opts {
+		opt(ai)
 	}
+	if ai.difficulty == Hard && ai.ttSize > 0 {
+		ai.tt = NewTranspositionTable(ai.ttSize)
+	}
+	return ai
 }
 
 func (ai *AIPlayer) Name() string {
@@ -47,29 +197,118 @@ func (ai *AIPlayer) Name() string {
 		return "AI (Medium)"
 	case Hard:
 		return "AI (Hard)"
+	case MCTS:
+		return "AI (MCTS)"
 	default:
 		return "AI"
 	}
 }
 
+// Difficulty returns the AI's difficulty level
+func (ai *AIPlayer) Difficulty() Difficulty {
+	return ai.difficulty
+}
+
+// Weights returns the AI's evaluation weights
+func (ai *AIPlayer) Weights() EvalWeights {
+	return ai.weights
+}
+
+// Identity names the AI's configuration, e.g. "hard think=2s", for ratings
+// Settings left at their defaults are omitted, and the seed never counts
+func (ai *AIPlayer) Identity() string {
+	parts := []string{ai.difficulty.String()}
+	if ai.difficulty != Easy && ai.weights != DefaultWeights() {
+		parts = append(parts, "weights="+ai.weights.Name)
+	}
+
+	switch ai.difficulty {
+	case Hard:
+		if ai.search != SearchAuto {
+			parts = append(parts, "search="+ai.search.String())
+		}
+		if ai.thinkTime > 0 {
+			parts = append(parts, "think="+ai.thinkTime.String())
+		}
+	case MCTS:
+		def := DefaultMCTSConfig()
+		if ai.mcts.Iterations != def.Iterations {
+			parts = append(parts, fmt.Sprintf("iterations=%d", ai.mcts.Iterations))
+		}
+		if ai.mcts.TimeLimit != def.TimeLimit {
+			parts = append(parts, "time="+ai.mcts.TimeLimit.String())
+		}
+		if ai.mcts.Exploration != def.Exploration {
+			parts = append(parts, fmt.Sprintf("exploration=%g", ai.mcts.Exploration))
+		}
+		if ai.mcts.Playout == RandomPlayout {
+			parts = append(parts, "playout=random")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// TTStats returns the transposition table activity of the AI's searches so far
+// It is zero for AIs without a table
+func (ai *AIPlayer) TTStats() TTStats {
+	if ai.tt == nil {
+		return TTStats{}
+	}
+	return ai.tt.Stats()
+}
+
 // ChooseMove selects the best move based on difficulty
 func (ai *AIPlayer) ChooseMove(g *game.Game, moves []game.Move) game.Move {
+	return ai.ChooseMoveContext(context.Background(), g, moves)
+}
+
+// ChooseMoveContext selects the best move, thinking until ctx is done at the latest
+// A think time set with WithThinkTime further limits the search
+func (ai *AIPlayer) ChooseMoveContext(ctx context.Context, g *game.Game, moves []game.Move) game.Move {
 	if len(moves) == 0 {
 		return game.Move{}
 	}
 
+	if ai.thinkTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ai.thinkTime)
+		defer cancel()
+	}
+
+	// Gray Wall column choices are scored directly rather than searched
+	if g.Phase == game.PhaseWallTiling && ai.difficulty != Easy {
+		return ai.wallTilingMove(g, moves)
+	}
+
 	switch ai.difficulty {
 	case Easy:
 		return ai.randomMove(moves)
 	case Medium:
 		return ai.heuristicMove(g, moves)
 	case Hard:
-		return ai.minimaxMove(g, moves)
+		if ai.searchMode(g) == SearchMaxN {
+			return ai.maxnMove(ctx, g, moves)
+		}
+		return ai.minimaxMove(ctx, g, moves)
+	case MCTS:
+		return ai.mctsMove(ctx, g, moves)
 	default:
 		return ai.randomMove(moves)
 	}
 }
 
+// searchMode resolves SearchAuto for the game's player count
+func (ai *AIPlayer) searchMode(g *game.Game) SearchMode {
+	if ai.search != SearchAuto {
+		return ai.search
+	}
+	if g.NumPlayers > 2 {
+		return SearchMaxN
+	}
+	return SearchParanoid
+}
+
 // randomMove picks a random legal move
 func (ai *AIPlayer) randomMove(moves []game.Move) game.Move {
 	return moves[ai.rng.Intn(len(moves))]
@@ -94,10 +333,23 @@ func (ai *AIPlayer) heuristicMove(g *game.Game, moves []game.Move) game.Move {
 	return bestMoves[ai.rng.Intn(len(bestMoves))]
 }
 
-// evaluateMove scores a move using heuristics
+// evaluateMove scores a move for the player to move using heuristics
 func (ai *AIPlayer) evaluateMove(g *game.Game, move game.Move) int {
+	return ai.moveTerms(g, move, nil)
+}
+
+// moveTerms scores a move for the player to move using heuristics
+// If note isn't nil, it is given every nonzero term of the score
+func (ai *AIPlayer) moveTerms(g *game.Game, move game.Move, note func(Reason)) int {
+	w := &ai.weights
 	score := 0
-	player := g.Players[ai.playerIdx]
+	add := func(value int, text string) {
+		score += value
+		if note != nil && value != 0 {
+			note(Reason{Text: text, Value: value})
+		}
+	}
+	player := g.Players[g.CurrentPlayer]
 
 	// Prefer completing pattern lines
 	if move.LineIdx >= 0 {
@@ -122,44 +374,47 @@ func (ai *AIPlayer) evaluateMove(g *game.Game, move game.Move) int {
 
 		// Bonus for completing a line
 		if tileCount >= tilesNeeded {
-			score += 50 + (move.LineIdx+1)*10 // Bigger lines = more points
+			add(scaled(w.CompleteLine, 1)+scaled(w.CompleteLineSize, move.LineIdx+1), "completes the pattern line") // Bigger lines = more points
 		}
 
 		// Penalty for overflow
 		overflow := tileCount - tilesNeeded
 		if overflow > 0 {
-			score -= overflow * 15
+			add(-scaled(w.Overflow, overflow), "tiles overflow to the floor")
 		}
 
 		// Prefer filling larger lines
-		score += move.LineIdx * 5
+		add(scaled(w.LineIndex, move.LineIdx), "larger pattern line")
 	} else {
 		// Floor placement is bad
-		score -= 30
+		add(-scaled(w.FloorMove, 1), "all tiles go to the floor")
 	}
 
 	// Avoid taking first player marker early in round
 	if move.FactoryIdx == -1 && g.Center.HasFirstPlayerTile {
-		if len(g.Center.Tiles) < 5 {
-			score -= 20 // Penalty for taking 1st player with few tiles
+		if len(g.Center.Tiles) < w.EarlyFirstPlayerAt {
+			add(-scaled(w.EarlyFirstPlayer, 1), "takes the first player marker early") // Penalty for taking 1st player with few tiles
 		}
 	}
 
 	// Bonus for colors that help complete rows/columns/color sets
-	score += ai.evaluateBoardProgress(player, move)
+	ai.evaluateBoardProgress(player, move, add)
 
 	return score
 }
 
-// evaluateBoardProgress checks if a move helps with bonus scoring
-func (ai *AIPlayer) evaluateBoardProgress(player *game.PlayerBoard, move game.Move) int {
+// evaluateBoardProgress checks if a move helps with bonus scoring, passing each bonus to add
+func (ai *AIPlayer) evaluateBoardProgress(player *game.PlayerBoard, move game.Move, add func(value int, text string)) {
 	if move.LineIdx < 0 {
-		return 0
+		return
 	}
 
-	score := 0
+	w := &ai.weights
 	row := move.LineIdx
-	col := player.GetWallColumn(row, move.Color)
+	cols := player.WallColumnOptions(row, move.Color)
+	if len(cols) == 0 {
+		return // Line can't be tiled (Gray Wall column conflict)
+	}
 
 	// Check row completion progress
 	rowFilled := 0
@@ -169,82 +424,135 @@ func (ai *AIPlayer) evaluateBoardProgress(player *game.PlayerBoard, move game.Mo
 		}
 	}
 	if rowFilled >= 3 {
-		score += 10 // Close to completing row
+		add(scaled(w.RowProgress, 1), "wall row nearly complete") // Close to completing row
 	}
 
-	// Check column completion progress
+	// Check column completion progress (best candidate column on the Gray Wall)
 	colFilled := 0
-	for r := 0; r < 5; r++ {
-		if player.Wall[r][col] {
-			colFilled++
+	for _, col := range cols {
+		filled := 0
+		for r := 0; r < 5; r++ {
+			if player.Wall[r][col] {
+				filled++
+			}
 		}
+		colFilled = max(colFilled, filled)
 	}
 	if colFilled >= 3 {
-		score += 15 // Close to completing column (worth more)
+		add(scaled(w.ColumnProgress, 1), "wall column nearly complete") // Close to completing column (worth more)
 	}
 
 	// Check color completion progress
-	colorCount := 0
-	for r := 0; r < 5; r++ {
-		c := player.GetWallColumn(r, move.Color)
-		if player.Wall[r][c] {
-			colorCount++
-		}
+	if player.ColorCount(move.Color) >= 3 {
+		add(scaled(w.ColorProgress, 1), "color nearly complete on the wall") // Close to completing color set (worth most)
 	}
-	if colorCount >= 3 {
-		score += 20 // Close to completing color set (worth most)
+}
+
+// wallTilingMove picks a Gray Wall column for a full pattern line
+// Each column is scored by the points it earns now plus the bonus potential it leaves
+func (ai *AIPlayer) wallTilingMove(g *game.Game, moves []game.Move) game.Move {
+	bestScore := math.MinInt32
+	var bestMoves []game.Move
+
+	for _, move := range moves {
+		score := ai.tilingTerms(g, move, nil)
+		if score > bestScore {
+			bestScore = score
+			bestMoves = []game.Move{move}
+		} else if score == bestScore {
+			bestMoves = append(bestMoves, move)
+		}
 	}
 
+	return bestMoves[ai.rng.Intn(len(bestMoves))]
+}
+
+// tilingTerms scores a Gray Wall column choice
+// If note isn't nil, it is given every nonzero term of the score
+func (ai *AIPlayer) tilingTerms(g *game.Game, move game.Move, note func(Reason)) int {
+	player := g.Players[g.CurrentPlayer]
+	board := player.Clone()
+	board.TileLine(move.LineIdx, move.Column)
+
+	terms := []Reason{
+		{Text: "points scored by the placement", Value: scaled(ai.weights.TilingPoints, board.Score-player.Score)},
+		{Text: "wall bonus potential", Value: scaled(ai.weights.TilingPotential, ai.evaluateWallPotential(board))},
+	}
+	score := 0
+	for _, t := range terms {
+		score += t.Value
+		if note != nil && t.Value != 0 {
+			note(t)
+		}
+	}
 	return score
 }
 
 // minimaxMove uses minimax with alpha-beta pruning
-func (ai *AIPlayer) minimaxMove(g *game.Game, moves []game.Move) game.Move {
+// With more than 2 players this is paranoid search: all opponents act as one minimizer
+func (ai *AIPlayer) minimaxMove(ctx context.Context, g *game.Game, moves []game.Move) game.Move {
 	if len(moves) == 0 {
 		return game.Move{}
 	}
 
-	bestScore := math.MinInt32
-	bestMove := moves[0]
-	appliedAny := false
-
-	// Limit search depth based on game state
-	depth := 4
-	if len(moves) > 20 {
-		depth = 3
+	ranked, scores := ai.minimaxRanking(ctx, g, moves)
+	if scores == nil {
+		// Stopped before the first search finished
+		return ai.heuristicMove(g, moves)
 	}
+	return ranked[0]
+}
+
+// minimaxRanking searches every move with minimax and returns them best first (see rankMoves)
+func (ai *AIPlayer) minimaxRanking(ctx context.Context, g *game.Game, moves []game.Move) ([]game.Move, []int) {
+	// Search a copy with the bag order resampled, so the round-end leaves,
+	// which hold the next round's factories, can't show the real refill
+	root := g.Determinize(ai.rng)
+
+	return ai.rankMoves(ctx, moves, legacyDepth(moves, 4, 3), func(s *search, ordered []game.Move, depth int) []int {
+		return ai.minimaxRoot(s, root, ordered, depth)
+	})
+}
+
+// minimaxRoot scores every root move with a full window search to the given depth
+// Moves that fail to apply score math.MinInt32
+func (ai *AIPlayer) minimaxRoot(s *search, root *game.Game, moves []game.Move, depth int) []int {
+	s.round = root.Round
+	scores := make([]int, len(moves))
+
+	for i, move := range moves {
+		scores[i] = math.MinInt32
 
-	for _, move := range moves {
 		// Clone and apply move
-		clone := g.Clone()
+		clone := root.Clone()
 		if err := clone.ApplyMove(move); err != nil {
 			// Skip invalid moves that fail to apply
 			continue
 		}
-		appliedAny = true
 
 		// Determine if the AI is the next player to move (maximizing)
 		// After applying the move, check who the current player is in the cloned game
 		isAINext := clone.CurrentPlayer == ai.playerIdx
-		score := ai.minimax(clone, depth-1, math.MinInt32, math.MaxInt32, isAINext)
+		scores[i] = ai.minimax(s, clone, depth-1, math.MinInt32, math.MaxInt32, isAINext)
 
-		if score > bestScore {
-			bestScore = score
-			bestMove = move
+		if s.stopped() {
+			break
 		}
 	}
 
-	if !appliedAny {
-		return bestMove
-	}
-
-	return bestMove
+	return scores
 }
 
 // minimax with alpha-beta pruning
-func (ai *AIPlayer) minimax(g *game.Game, depth int, alpha, beta int, maximizing bool) int {
+// Results are cached in the transposition table, if the AI has one, so
+// positions reached by different move orders are only searched once
+func (ai *AIPlayer) minimax(s *search, g *game.Game, depth int, alpha, beta int, maximizing bool) int {
 	// Terminal conditions
-	if depth == 0 || g.GameOver || g.IsRoundOver() {
+	if s.leaf(g) {
+		return ai.evaluateState(g)
+	}
+	if depth == 0 || s.stopped() {
+		s.truncated = true
 		return ai.evaluateState(g)
 	}
 
@@ -253,87 +561,152 @@ func (ai *AIPlayer) minimax(g *game.Game, depth int, alpha, beta int, maximizing
 		return ai.evaluateState(g)
 	}
 
-	if maximizing {
-		maxEval := math.MinInt32
-		anyValid := false
-		for _, move := range moves {
-			clone := g.Clone()
-			if err := clone.ApplyMove(move); err != nil {
-				// Skip invalid moves that fail to apply
-				continue
+	var key uint64
+	alphaOrig, betaOrig := alpha, beta
+	if ai.tt != nil {
+		key = g.Hash()
+		if entry, ok := ai.tt.probe(key); ok {
+			if entry.complete || entry.depth >= depth {
+				switch entry.bound {
+				case ttExact:
+					alpha, beta = entry.value, entry.value
+				case ttLower:
+					alpha = max(alpha, entry.value)
+				case ttUpper:
+					beta = min(beta, entry.value)
+				}
+				if beta <= alpha {
+					ai.tt.stats.Cutoffs++
+					if !entry.complete {
+						s.truncated = true
+					}
+					return entry.value
+				}
+			}
+			if entry.hasBest {
+				moves = bestFirst(moves, entry.best)
 			}
-			anyValid = true
+		}
+	}
+
+	// Track truncation below this node separately, to know whether the
+	// result holds at any depth
+	outerTruncated := s.truncated
+	s.truncated = false
 
-			eval := ai.minimax(clone, depth-1, alpha, beta, clone.CurrentPlayer == ai.playerIdx)
-			maxEval = max(maxEval, eval)
+	best := math.MaxInt32
+	if maximizing {
+		best = math.MinInt32
+	}
+	var bestMove game.Move
+	anyValid := false
+	for _, move := range moves {
+		clone := g.Clone()
+		if err := clone.ApplyMove(move); err != nil {
+			// Skip invalid moves that fail to apply
+			continue
+		}
+
+		eval := ai.minimax(s, clone, depth-1, alpha, beta, clone.CurrentPlayer == ai.playerIdx)
+		if maximizing && (eval > best || !anyValid) {
+			best, bestMove = eval, move
 			alpha = max(alpha, eval)
+		} else if !maximizing && (eval < best || !anyValid) {
+			best, bestMove = eval, move
+			beta = min(beta, eval)
+		}
+		anyValid = true
 
-			if beta <= alpha {
-				break
-			}
+		if beta <= alpha {
+			break
 		}
-		if !anyValid {
-			return ai.evaluateState(g)
+	}
+
+	truncated := s.truncated
+	s.truncated = outerTruncated || truncated
+
+	if !anyValid {
+		return ai.evaluateState(g)
+	}
+
+	// An aborted search returns a partial result that must not be cached
+	if ai.tt != nil && !s.stopped() {
+		bound := ttExact
+		if best <= alphaOrig {
+			bound = ttUpper
+		} else if best >= betaOrig {
+			bound = ttLower
 		}
-		return maxEval
-	} else {
-		minEval := math.MaxInt32
-		anyValid := false
-		for _, move := range moves {
-			clone := g.Clone()
-			if err := clone.ApplyMove(move); err != nil {
-				// Skip invalid moves that fail to apply
-				continue
-			}
-			anyValid = true
+		ai.tt.store(ttEntry{
+			key:      key,
+			depth:    depth,
+			value:    best,
+			bound:    bound,
+			complete: !truncated,
+			best:     bestMove,
+			hasBest:  true,
+		})
+	}
 
-			eval := ai.minimax(clone, depth-1, alpha, beta, clone.CurrentPlayer == ai.playerIdx)
-			minEval = min(minEval, eval)
-			beta = min(beta, eval)
+	return best
+}
 
-			if beta <= alpha {
-				break
+// bestFirst returns moves with best moved to the front
+func bestFirst(moves []game.Move, best game.Move) []game.Move {
+	for i, move := range moves {
+		if move == best {
+			if i > 0 {
+				moves[0], moves[i] = moves[i], moves[0]
 			}
+			break
 		}
-		if !anyValid {
-			return ai.evaluateState(g)
-		}
-		return minEval
 	}
+	return moves
 }
 
 // evaluateState scores the current game state for the AI player
 func (ai *AIPlayer) evaluateState(g *game.Game) int {
-	myPlayer := g.Players[ai.playerIdx]
-	score := myPlayer.Score * 10
+	return ai.evaluateStateFor(g, ai.playerIdx)
+}
+
+// evaluateStateFor scores the game state from one seat's point of view
+// Only the strongest opponent's score counts against the seat: summing every
+// opponent would make 3-4 player positions look far worse than they are
+func (ai *AIPlayer) evaluateStateFor(g *game.Game, seat int) int {
+	w := &ai.weights
+	myPlayer := g.Players[seat]
+	score := scaled(w.Score, myPlayer.Score)
 
 	// Evaluate pattern line progress
 	for i, pl := range myPlayer.PatternLines {
 		if pl.Filled > 0 {
 			// Partial credit for progress
 			progress := float64(pl.Filled) / float64(pl.Size)
-			score += int(progress * float64(i+1) * 5)
+			score += int(progress * float64(i+1) * w.LineProgress)
 		}
 	}
 
 	// Evaluate wall bonuses potential
-	score += ai.evaluateWallPotential(myPlayer) * 2
+	score += scaled(w.WallPotential, ai.evaluateWallPotential(myPlayer))
 
-	// Subtract opponent scores
+	// Subtract the strongest opponent's score
+	bestOpponent := 0
 	for i, player := range g.Players {
-		if i != ai.playerIdx {
-			score -= player.Score * 8
+		if i != seat {
+			bestOpponent = max(bestOpponent, player.Score)
 		}
 	}
+	score -= scaled(w.OpponentScore, bestOpponent)
 
 	// Penalty for floor tiles
-	score -= len(myPlayer.FloorLine) * 5
+	score -= scaled(w.FloorTile, len(myPlayer.FloorLine))
 
 	return score
 }
 
 // evaluateWallPotential estimates bonus scoring potential
 func (ai *AIPlayer) evaluateWallPotential(player *game.PlayerBoard) int {
+	w := &ai.weights
 	score := 0
 
 	// Row completion potential
@@ -345,9 +718,9 @@ func (ai *AIPlayer) evaluateWallPotential(player *game.PlayerBoard) int {
 			}
 		}
 		if filled >= 4 {
-			score += 10
+			score += scaled(w.Row4, 1)
 		} else if filled >= 3 {
-			score += 5
+			score += scaled(w.Row3, 1)
 		}
 	}
 
@@ -360,25 +733,19 @@ func (ai *AIPlayer) evaluateWallPotential(player *game.PlayerBoard) int {
 			}
 		}
 		if filled >= 4 {
-			score += 20
+			score += scaled(w.Column4, 1)
 		} else if filled >= 3 {
-			score += 10
+			score += scaled(w.Column3, 1)
 		}
 	}
 
 	// Color completion potential
 	for _, color := range game.AllColors() {
-		count := 0
-		for row := 0; row < 5; row++ {
-			col := player.GetWallColumn(row, color)
-			if player.Wall[row][col] {
-				count++
-			}
-		}
+		count := player.ColorCount(color)
 		if count >= 4 {
-			score += 25
+			score += scaled(w.Color4, 1)
 		} else if count >= 3 {
-			score += 12
+			score += scaled(w.Color3, 1)
 		}
 	}
 
@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// Reason is one term of a move's heuristic score
+type Reason struct {
+	Text  string // What the term rewards or penalizes
+	Value int
+}
+
+// MoveAnalysis is a legal move with the AI's assessment of it
+type MoveAnalysis struct {
+	Move game.Move
+
+	// Eval is what the AI ranks moves by: the search value for Hard, the
+	// average playout reward in percent for MCTS, the heuristic score otherwise
+	Eval int
+
+	// Searched is true when Eval comes from Hard's search or MCTS's tree, and
+	// false when it is the heuristic score, e.g. because the think time ran
+	// out before the first search iteration finished
+	Searched bool
+
+	// Swing is the change in the mover's points once the round is scored
+	// (full pattern lines tiled, floor penalties taken)
+	Swing int
+
+	// Why lists the heuristic terms behind the move
+	Why []Reason
+}
+
+// Analyze ranks the legal moves for the player to move, best first
+// The AI must be the one for that seat. Hard searches every move and MCTS
+// builds its tree, within the AI's think time or until ctx is done; Easy and
+// Medium (and every level placing a Gray Wall line) rank by the heuristic.
+// It returns the top n moves, or all of them if n <= 0.
+func (ai *AIPlayer) Analyze(ctx context.Context, g *game.Game, n int) []MoveAnalysis {
+	moves := g.GetValidMoves()
+	if len(moves) == 0 {
+		return nil
+	}
+
+	if ai.thinkTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ai.thinkTime)
+		defer cancel()
+	}
+
+	// Heuristic scores and terms of every move
+	analyses := make([]MoveAnalysis, len(moves))
+	index := make(map[game.Move]int, len(moves))
+	before := g.Players[g.CurrentPlayer].ProjectRound(false).Score
+	for i, move := range moves {
+		a := MoveAnalysis{Move: move}
+		note := func(r Reason) { a.Why = append(a.Why, r) }
+		if g.Phase == game.PhaseWallTiling {
+			a.Eval = ai.tilingTerms(g, move, note)
+		} else {
+			a.Eval = ai.moveTerms(g, move, note)
+		}
+
+		after := g.Clone()
+		if err := after.ApplyMove(move); err == nil {
+			a.Swing = after.Players[g.CurrentPlayer].ProjectRound(false).Score - before
+		}
+
+		analyses[i] = a
+		index[move] = i
+	}
+	ranked := slices.Clone(analyses)
+	sort.SliceStable(ranked, func(a, b int) bool { return ranked[a].Eval > ranked[b].Eval })
+
+	if g.Phase == game.PhaseDrafting {
+		switch ai.difficulty {
+		case Hard:
+			var order []game.Move
+			var scores []int
+			if ai.searchMode(g) == SearchMaxN {
+				order, scores = ai.maxnRanking(ctx, g, moves)
+			} else {
+				order, scores = ai.minimaxRanking(ctx, g, moves)
+			}
+			if scores != nil {
+				ranked = make([]MoveAnalysis, len(order))
+				for i, move := range order {
+					ranked[i] = analyses[index[move]]
+					ranked[i].Eval = scores[i]
+					ranked[i].Searched = true
+				}
+			}
+		case MCTS:
+			if root := ai.mctsSearch(ctx, g, moves); len(root.children) > 0 {
+				ranked = mctsRanking(root, ranked)
+			}
+		}
+	}
+
+	if n > 0 && n < len(ranked) {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// mctsRanking orders moves by their visits in an MCTS tree, most visited first
+// Moves the search never tried follow in their heuristic order.
+func mctsRanking(root *mctsNode, heuristic []MoveAnalysis) []MoveAnalysis {
+	children := append([]*mctsNode(nil), root.children...)
+	sort.SliceStable(children, func(a, b int) bool { return children[a].visits > children[b].visits })
+
+	byMove := make(map[game.Move]MoveAnalysis, len(heuristic))
+	for _, a := range heuristic {
+		byMove[a.Move] = a
+	}
+
+	ranked := make([]MoveAnalysis, 0, len(heuristic))
+	tried := make(map[game.Move]bool, len(children))
+	for _, child := range children {
+		a := byMove[child.move]
+		a.Eval = int(math.Round(100 * child.reward / float64(max(child.visits, 1))))
+		a.Searched = true
+		ranked = append(ranked, a)
+		tried[child.move] = true
+	}
+	for _, a := range heuristic {
+		if !tried[a.Move] {
+			a.Eval = 0
+			ranked = append(ranked, a)
+		}
+	}
+	return ranked
+}
@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+func TestAnalyzeRanksLegalMoves(t *testing.T) {
+	g := game.NewGameWithSeed(2, 1)
+	moves := g.GetValidMoves()
+	const n = 5
+
+	for _, level := range []Difficulty{Easy, Medium, Hard, MCTS} {
+		p := NewAIPlayer(level, 0, WithSeed(1), WithMCTS(MCTSConfig{Iterations: 200, Exploration: 1}))
+		analyses := p.Analyze(context.Background(), g, n)
+		if len(analyses) != n {
+			t.Fatalf("%s: got %d moves, want %d", level, len(analyses), n)
+		}
+
+		seen := make(map[game.Move]bool)
+		for i, a := range analyses {
+			if !slices.Contains(moves, a.Move) {
+				t.Errorf("%s: %s is not a legal move", level, game.FormatMove(a.Move))
+			}
+			if seen[a.Move] {
+				t.Errorf("%s: %s is listed twice", level, game.FormatMove(a.Move))
+			}
+			seen[a.Move] = true
+
+			if searched := level == Hard || level == MCTS; a.Searched != searched {
+				t.Errorf("%s: Searched = %v, want %v", level, a.Searched, searched)
+			}
+			// MCTS ranks by visits, the others by Eval
+			if level != MCTS && i > 0 && a.Eval > analyses[i-1].Eval {
+				t.Errorf("%s: move %d (%d) is ranked below move %d (%d)", level, i+1, a.Eval, i, analyses[i-1].Eval)
+			}
+		}
+	}
+
+	if all := NewAIPlayer(Medium, 0).Analyze(context.Background(), g, 0); len(all) != len(moves) {
+		t.Errorf("Analyze(0) listed %d of the %d legal moves", len(all), len(moves))
+	}
+}
@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"context"
+	"math"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// maxnMove searches with max-n: each seat picks the move best for itself
+// Leaves are scored for every seat separately (see evaluateStateFor). There is
+// no alpha-beta pruning, so the search is shallower than two-player minimax.
+func (ai *AIPlayer) maxnMove(ctx context.Context, g *game.Game, moves []game.Move) game.Move {
+	ranked, scores := ai.maxnRanking(ctx, g, moves)
+	if scores == nil {
+		// Stopped before the first search finished
+		return ai.heuristicMove(g, moves)
+	}
+	return ranked[0]
+}
+
+// maxnRanking searches every move with max-n and returns them best first (see rankMoves)
+func (ai *AIPlayer) maxnRanking(ctx context.Context, g *game.Game, moves []game.Move) ([]game.Move, []int) {
+	root := g.Determinize(ai.rng)
+
+	return ai.rankMoves(ctx, moves, legacyDepth(moves, 3, 2), func(s *search, ordered []game.Move, depth int) []int {
+		return ai.maxnRoot(s, root, ordered, depth)
+	})
+}
+
+// maxnRoot scores every root move for the AI's seat
+// Moves that fail to apply score math.MinInt32
+func (ai *AIPlayer) maxnRoot(s *search, root *game.Game, moves []game.Move, depth int) []int {
+	s.round = root.Round
+	scores := make([]int, len(moves))
+
+	for i, move := range moves {
+		scores[i] = math.MinInt32
+
+		clone := root.Clone()
+		if err := clone.ApplyMove(move); err != nil {
+			continue
+		}
+
+		scores[i] = ai.maxn(s, clone, depth-1)[ai.playerIdx]
+		if s.stopped() {
+			break
+		}
+	}
+
+	return scores
+}
+
+// maxn returns the value vector (one entry per seat) of a position
+func (ai *AIPlayer) maxn(s *search, g *game.Game, depth int) []int {
+	if s.leaf(g) {
+		return ai.evaluateSeats(g)
+	}
+	if depth == 0 || s.stopped() {
+		s.truncated = true
+		return ai.evaluateSeats(g)
+	}
+
+	mover := g.CurrentPlayer
+	var best []int
+	for _, move := range g.GetValidMoves() {
+		clone := g.Clone()
+		if err := clone.ApplyMove(move); err != nil {
+			continue
+		}
+
+		values := ai.maxn(s, clone, depth-1)
+		if best == nil || values[mover] > best[mover] {
+			best = values
+		}
+	}
+
+	if best == nil {
+		return ai.evaluateSeats(g)
+	}
+	return best
+}
+
+// evaluateSeats scores the position from every seat's point of view
+func (ai *AIPlayer) evaluateSeats(g *game.Game) []int {
+	values := make([]int, g.NumPlayers)
+	for seat := range values {
+		values[seat] = ai.evaluateStateFor(g, seat)
+	}
+	return values
+}
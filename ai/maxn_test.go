@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+func TestSearchModes(t *testing.T) {
+	for _, tc := range []struct {
+		players int
+		mode    SearchMode
+		want    SearchMode
+	}{
+		{2, SearchAuto, SearchParanoid},
+		{3, SearchAuto, SearchMaxN},
+		{4, SearchAuto, SearchMaxN},
+		{2, SearchMaxN, SearchMaxN},
+		{3, SearchParanoid, SearchParanoid},
+	} {
+		g := game.NewGameWithSeed(tc.players, 1)
+		if got := NewAIPlayer(Hard, 0, WithSearchMode(tc.mode)).searchMode(g); got != tc.want {
+			t.Errorf("%d players, %s: searched with %s, want %s", tc.players, tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestSearchModesOnThreePlayers(t *testing.T) {
+	// Play Medium's moves until paranoid and max-n disagree, in a position with
+	// few enough moves to search quickly
+	g := game.NewGameWithSeed(3, 1)
+	medium := NewAIPlayer(Medium, 0, WithSeed(1))
+	for !g.GameOver {
+		moves := g.GetValidMoves()
+		if g.Phase == game.PhaseDrafting && g.CurrentPlayer == 0 && len(moves) > 1 && len(moves) <= 15 {
+			chosen := make(map[SearchMode]game.Move)
+			for _, mode := range []SearchMode{SearchAuto, SearchParanoid, SearchMaxN} {
+				p := NewAIPlayer(Hard, 0, WithSeed(1), WithSearchMode(mode))
+				move := p.ChooseMove(g, moves)
+				if !slices.Contains(moves, move) {
+					t.Fatalf("%s chose %s, which is not a legal move", mode, game.FormatMove(move))
+				}
+				chosen[mode] = move
+			}
+			if chosen[SearchParanoid] != chosen[SearchMaxN] {
+				if chosen[SearchAuto] != chosen[SearchMaxN] {
+					t.Errorf("auto chose %s and max-n %s; auto should search 3 players with max-n",
+						game.FormatMove(chosen[SearchAuto]), game.FormatMove(chosen[SearchMaxN]))
+				}
+				return
+			}
+		}
+		if err := g.ApplyMove(medium.heuristicMove(g, moves)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Fatal("paranoid and max-n agreed on every move, so the test proves nothing")
+}
@@ -0,0 +1,244 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// PlayoutPolicy selects how MCTS plays out positions beyond the search tree
+type PlayoutPolicy int
+
+const (
+	RandomPlayout    PlayoutPolicy = iota // Uniformly random legal moves
+	HeuristicPlayout                      // Best of a few sampled moves by evaluateMove
+)
+
+// MCTSConfig controls the MCTS difficulty
+// The search stops at whichever budget runs out first; a zero budget is unlimited,
+// but at least one of the two must be set
+type MCTSConfig struct {
+	Iterations  int           // Playouts per move (0 = limited by time only)
+	TimeLimit   time.Duration // Thinking time per move (0 = limited by iterations only)
+	Exploration float64       // UCT exploration constant
+	Playout     PlayoutPolicy
+}
+
+// DefaultMCTSConfig returns the budget used when none is given
+func DefaultMCTSConfig() MCTSConfig {
+	return MCTSConfig{
+		Iterations:  3000,
+		TimeLimit:   3 * time.Second,
+		Exploration: 1.0,
+		Playout:     HeuristicPlayout,
+	}
+}
+
+// playoutSample is how many legal moves a heuristic playout compares per turn
+const playoutSample = 6
+
+// mctsNode is a position in the search tree, reached by move
+type mctsNode struct {
+	move     game.Move
+	player   int // Seat that played move (whose reward this node collects)
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []game.Move
+	visits   int
+	reward   float64
+	frontier bool // move ended the round: what follows depends on the bag
+}
+
+// mctsMove searches with UCT and returns the most visited move
+//
+// Every iteration starts from a fresh determinization of the game, so the AI
+// never relies on the real (hidden) draw order. The tree only covers
+// the current round, where every move is public; from the end of the round
+// onward, playouts continue through later rounds to the end of the game.
+//
+// The search also stops when ctx is done, returning the best move found so far.
+func (ai *AIPlayer) mctsMove(ctx context.Context, g *game.Game, moves []game.Move) game.Move {
+	if len(moves) == 1 {
+		return moves[0]
+	}
+
+	root := ai.mctsSearch(ctx, g, moves)
+	if len(root.children) == 0 {
+		// Stopped before the first iteration
+		return ai.heuristicMove(g, moves)
+	}
+
+	best := root.children[0]
+	for _, child := range root.children[1:] {
+		if child.visits > best.visits {
+			best = child
+		}
+	}
+	return best.move
+}
+
+// mctsSearch builds the search tree for the position and returns its root
+func (ai *AIPlayer) mctsSearch(ctx context.Context, g *game.Game, moves []game.Move) *mctsNode {
+	cfg := ai.mcts
+	if cfg.Iterations <= 0 && cfg.TimeLimit <= 0 {
+		cfg.Iterations = DefaultMCTSConfig().Iterations
+	}
+	var deadline time.Time
+	if cfg.TimeLimit > 0 {
+		deadline = time.Now().Add(cfg.TimeLimit)
+	}
+
+	root := &mctsNode{player: -1, untried: append([]game.Move(nil), moves...)}
+
+	for i := 0; cfg.Iterations <= 0 || i < cfg.Iterations; i++ {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		state := g.Determinize(ai.rng)
+		node := root
+
+		// Selection: descend through fully expanded nodes
+		for len(node.untried) == 0 && len(node.children) > 0 {
+			node = node.selectChild(cfg.Exploration)
+			if err := state.ApplyMove(node.move); err != nil {
+				break
+			}
+		}
+
+		// Expansion: add one untried move
+		if len(node.untried) > 0 {
+			idx := ai.rng.Intn(len(node.untried))
+			move := node.untried[idx]
+			node.untried[idx] = node.untried[len(node.untried)-1]
+			node.untried = node.untried[:len(node.untried)-1]
+
+			player := state.CurrentPlayer
+			round := state.Round
+			if err := state.ApplyMove(move); err == nil {
+				child := &mctsNode{move: move, player: player, parent: node}
+				child.frontier = state.Round != round || state.GameOver
+				if !child.frontier {
+					child.untried = state.GetValidMoves()
+				}
+				node.children = append(node.children, child)
+				node = child
+			}
+		}
+
+		// Simulation and backpropagation
+		rewards := ai.playout(state)
+		for n := node; n != nil; n = n.parent {
+			n.visits++
+			if n.player >= 0 {
+				n.reward += rewards[n.player]
+			}
+		}
+	}
+
+	return root
+}
+
+// selectChild picks the child with the highest UCT value
+func (n *mctsNode) selectChild(exploration float64) *mctsNode {
+	logVisits := math.Log(float64(n.visits))
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+
+	for _, child := range n.children {
+		value := child.reward/float64(child.visits) +
+			exploration*math.Sqrt(logVisits/float64(child.visits))
+		if value > bestValue {
+			bestValue = value
+			best = child
+		}
+	}
+	return best
+}
+
+// playout plays the game to the end and returns each seat's reward in [0, 1]
+func (ai *AIPlayer) playout(state *game.Game) []float64 {
+	for !state.GameOver {
+		moves := state.GetValidMoves()
+		if len(moves) == 0 {
+			break
+		}
+		if err := state.ApplyMove(ai.playoutMove(state, moves)); err != nil {
+			break
+		}
+	}
+
+	if !state.GameOver {
+		state.EndGame()
+	}
+	return outcomeRewards(state)
+}
+
+// playoutMove chooses a move during a playout
+func (ai *AIPlayer) playoutMove(state *game.Game, moves []game.Move) game.Move {
+	if ai.mcts.Playout == RandomPlayout || len(moves) == 1 {
+		return moves[ai.rng.Intn(len(moves))]
+	}
+	if state.Phase == game.PhaseWallTiling {
+		return ai.wallTilingMove(state, moves)
+	}
+
+	best := moves[ai.rng.Intn(len(moves))]
+	bestScore := ai.evaluateMove(state, best)
+	for i := 1; i < playoutSample; i++ {
+		move := moves[ai.rng.Intn(len(moves))]
+		if score := ai.evaluateMove(state, move); score > bestScore {
+			bestScore = score
+			best = move
+		}
+	}
+	return best
+}
+
+// outcomeRewards scores a finished game for every seat
+func outcomeRewards(g *game.Game) []float64 {
+	scores := make([]int, g.NumPlayers)
+	rows := make([]int, g.NumPlayers)
+	for p, player := range g.Players {
+		scores[p] = player.Score
+		rows[p] = player.CompletedRows()
+	}
+	winner := g.GetWinner()
+
+	rewards := make([]float64, g.NumPlayers)
+	for p := range rewards {
+		rewards[p] = OutcomeReward(scores, rows, winner, p)
+	}
+	return rewards
+}
+
+// OutcomeReward scores a seat's result in a finished game, in [0, 1]
+// scores and rows (completed wall rows) are per seat, and winner is -1 for a
+// shared victory, which is split between the seats that share it
+// (game.Leaders). Winning counts most; the score margin to the best opponent
+// breaks ties between wins and between losses, so close losses count for
+// something. MCTS playouts and weight tuning both use it.
+func OutcomeReward(scores, rows []int, winner, seat int) float64 {
+	bestOther := math.MinInt
+	for s, score := range scores {
+		if s != seat {
+			bestOther = max(bestOther, score)
+		}
+	}
+	margin := float64(scores[seat] - bestOther)
+
+	outcome := 0.0
+	switch leaders := game.Leaders(scores, rows); {
+	case seat == winner:
+		outcome = 1
+	case winner == -1 && slices.Contains(leaders, seat):
+		outcome = 1 / float64(len(leaders))
+	}
+	return 0.7*outcome + 0.3*(0.5+0.5*math.Tanh(margin/20))
+}
@@ -0,0 +1,42 @@
+package ai
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+func TestMCTSBudgets(t *testing.T) {
+	t.Run("iterations", func(t *testing.T) {
+		g := game.NewGameWithSeed(2, 1)
+		moves := g.GetValidMoves()
+		p := NewAIPlayer(MCTS, 0, WithSeed(1), WithMCTS(MCTSConfig{Iterations: 40, Exploration: 1}))
+
+		if root := p.mctsSearch(context.Background(), g, moves); root.visits != 40 {
+			t.Errorf("searched %d iterations, want 40", root.visits)
+		}
+		if move := p.ChooseMove(g, moves); !slices.Contains(moves, move) {
+			t.Errorf("chose %s, which is not a legal move", game.FormatMove(move))
+		}
+	})
+
+	t.Run("time", func(t *testing.T) {
+		g := game.NewGameWithSeed(3, 1)
+		moves := g.GetValidMoves()
+		const limit = 50 * time.Millisecond
+		p := NewAIPlayer(MCTS, 0, WithSeed(1), WithMCTS(MCTSConfig{TimeLimit: limit, Exploration: 1}))
+
+		start := time.Now()
+		move := p.ChooseMove(g, moves)
+		// The last playout may finish after the deadline
+		if elapsed := time.Since(start); elapsed > limit+200*time.Millisecond {
+			t.Errorf("thought for %v with a %v budget", elapsed, limit)
+		}
+		if !slices.Contains(moves, move) {
+			t.Errorf("chose %s, which is not a legal move", game.FormatMove(move))
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"context"
+	"sort"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// maxSearchDepth caps iterative deepening
+const maxSearchDepth = 24
+
+// search carries the state of one decision through a tree search
+type search struct {
+	ctx       context.Context
+	round     int  // Round of the root position; the search stops where it ends
+	aborted   bool // ctx ended mid-iteration: the iteration's scores are incomplete
+	truncated bool // some line was cut off by depth, so a deeper search could differ
+}
+
+// leaf reports whether g ends the search: the game or the root's round is over
+// On the Gray Wall the round's drafting ends with the factories and center
+// empty; in the standard game the last move scores the round and fills the
+// factories for the next one.
+func (s *search) leaf(g *game.Game) bool {
+	return g.GameOver || g.IsRoundOver() || g.Round != s.round
+}
+
+// stopped reports whether the search must stop
+func (s *search) stopped() bool {
+	if !s.aborted && s.ctx.Err() != nil {
+		s.aborted = true
+	}
+	return s.aborted
+}
+
+// rootSearch scores each move (in order) with a search to the given depth
+type rootSearch func(s *search, moves []game.Move, depth int) []int
+
+// legacyDepth is the fixed search depth used without a time budget
+func legacyDepth(moves []game.Move, narrow, wide int) int {
+	if len(moves) > 20 {
+		return wide
+	}
+	return narrow
+}
+
+// rankMoves runs a root search and returns the moves best first, with their scores
+//
+// Without a deadline on ctx the search runs once at fixedDepth. With one, it
+// deepens iteratively from depth 1, trying the previous iteration's best moves
+// first, until the deadline passes or a search finishes without cutting any
+// line short. An iteration interrupted by the deadline (or by ctx being
+// cancelled) is discarded; if not even the first one finished, the moves come
+// back in their original order with nil scores.
+func (ai *AIPlayer) rankMoves(ctx context.Context, moves []game.Move, fixedDepth int, run rootSearch) ([]game.Move, []int) {
+	if _, timed := ctx.Deadline(); !timed {
+		s := &search{ctx: ctx}
+		scores := run(s, moves, fixedDepth)
+		if s.aborted {
+			return moves, nil
+		}
+		return sortByScore(moves, scores)
+	}
+
+	ordered := append([]game.Move(nil), moves...)
+	var scores []int
+
+	for depth := 1; depth <= maxSearchDepth; depth++ {
+		s := &search{ctx: ctx}
+		iteration := run(s, ordered, depth)
+		if s.aborted {
+			break
+		}
+
+		// Order moves best first for the next iteration
+		ordered, scores = sortByScore(ordered, iteration)
+
+		if !s.truncated {
+			break // Searched to the end of the round everywhere
+		}
+	}
+
+	return ordered, scores
+}
+
+// sortByScore returns the moves and their scores ordered best first
+// Moves with equal scores keep their order
+func sortByScore(moves []game.Move, scores []int) ([]game.Move, []int) {
+	idx := make([]int, len(moves))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return scores[idx[a]] > scores[idx[b]]
+	})
+
+	sortedMoves := make([]game.Move, len(moves))
+	sortedScores := make([]int, len(moves))
+	for i, j := range idx {
+		sortedMoves[i] = moves[j]
+		sortedScores[i] = scores[j]
+	}
+	return sortedMoves, sortedScores
+}
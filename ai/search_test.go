@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// bestHeuristic returns the highest heuristic score among moves
+func bestHeuristic(p *AIPlayer, g *game.Game, moves []game.Move) int {
+	best := p.evaluateMove(g, moves[0])
+	for _, move := range moves[1:] {
+		best = max(best, p.evaluateMove(g, move))
+	}
+	return best
+}
+
+func TestHardFallsBackToHeuristic(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, mode := range []SearchMode{SearchParanoid, SearchMaxN} {
+		for _, tc := range []struct {
+			name string
+			ctx  context.Context
+			opts []Option
+		}{
+			{"think 1ns", context.Background(), []Option{WithThinkTime(time.Nanosecond)}},
+			{"cancelled", cancelled, nil},
+		} {
+			g := game.NewGameWithSeed(3, 1)
+			moves := g.GetValidMoves()
+			p := NewAIPlayer(Hard, 0, append(tc.opts, WithSeed(1), WithSearchMode(mode))...)
+			best := bestHeuristic(p, g, moves)
+			if p.evaluateMove(g, moves[0]) == best {
+				t.Fatal("the first legal move is also the heuristic's best, so the test proves nothing")
+			}
+
+			move := p.ChooseMoveContext(tc.ctx, g, moves)
+			if move == moves[0] {
+				t.Errorf("%s, %s: played the first legal move", mode, tc.name)
+			}
+			if p.evaluateMove(g, move) != best {
+				t.Errorf("%s, %s: played %s, not one of the heuristic's best moves", mode, tc.name, game.FormatMove(move))
+			}
+		}
+	}
+}
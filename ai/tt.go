@@ -0,0 +1,116 @@
+package ai
+
+import (
+	"fmt"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// DefaultTTSize is the number of transposition table entries the Hard AI uses
+const DefaultTTSize = 1 << 17
+
+// ttBound says how a stored value relates to the true minimax value
+type ttBound uint8
+
+const (
+	ttExact ttBound = iota // The value is exact
+	ttLower                // The search failed high: the value is a lower bound
+	ttUpper                // The search failed low: the value is an upper bound
+)
+
+// ttEntry is one searched position
+type ttEntry struct {
+	key      uint64
+	depth    int // Remaining depth the position was searched to
+	value    int
+	bound    ttBound
+	complete bool // No line below was cut off by depth: valid at any depth
+	best     game.Move
+	hasBest  bool
+	used     bool
+}
+
+// TranspositionTable caches alpha-beta results by Zobrist hash
+// It holds a fixed number of entries; a new result replaces the old one in its
+// slot unless the old one was searched deeper.
+type TranspositionTable struct {
+	entries []ttEntry
+	mask    uint64
+	stats   TTStats
+}
+
+// TTStats counts transposition table activity
+type TTStats struct {
+	Probes     int // Lookups
+	Hits       int // Lookups that found the position
+	Cutoffs    int // Hits that ended the search of the position
+	Stores     int // Results written
+	Overwrites int // Stores that replaced a different position
+}
+
+// HitRate returns the fraction of lookups that found the position
+func (s TTStats) HitRate() float64 {
+	if s.Probes == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Probes)
+}
+
+func (s TTStats) String() string {
+	return fmt.Sprintf("%d probes, %.1f%% hits, %d cutoffs", s.Probes, s.HitRate()*100, s.Cutoffs)
+}
+
+// NewTranspositionTable creates a table with room for about size entries
+// The size is rounded down to a power of two
+func NewTranspositionTable(size int) *TranspositionTable {
+	n := 1
+	for n*2 <= size {
+		n *= 2
+	}
+	return &TranspositionTable{
+		entries: make([]ttEntry, n),
+		mask:    uint64(n - 1),
+	}
+}
+
+// Stats returns the activity counted since the table was created or last reset
+func (t *TranspositionTable) Stats() TTStats {
+	return t.stats
+}
+
+// ResetStats zeroes the activity counters, keeping the stored results
+func (t *TranspositionTable) ResetStats() {
+	t.stats = TTStats{}
+}
+
+// Clear removes every stored result
+func (t *TranspositionTable) Clear() {
+	clear(t.entries)
+}
+
+// probe looks up a position
+func (t *TranspositionTable) probe(key uint64) (ttEntry, bool) {
+	t.stats.Probes++
+	e := t.entries[key&t.mask]
+	if !e.used || e.key != key {
+		return ttEntry{}, false
+	}
+	t.stats.Hits++
+	return e, true
+}
+
+// store saves a search result, keeping a deeper result for the same position
+func (t *TranspositionTable) store(e ttEntry) {
+	slot := &t.entries[e.key&t.mask]
+	if slot.used {
+		if slot.key == e.key && !e.complete && (slot.complete || slot.depth > e.depth) {
+			return
+		}
+		if slot.key != e.key {
+			t.stats.Overwrites++
+		}
+	}
+	e.used = true
+	*slot = e
+	t.stats.Stores++
+}
@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+func TestTranspositionTableStats(t *testing.T) {
+	tt := NewTranspositionTable(8)
+
+	if _, ok := tt.probe(1); ok {
+		t.Error("an empty table found a position")
+	}
+	tt.store(ttEntry{key: 1, depth: 3, value: 10})
+	e, ok := tt.probe(1)
+	if !ok || e.value != 10 {
+		t.Errorf("probe after store = %+v, %v; want value 10", e, ok)
+	}
+
+	// A shallower result doesn't replace a deeper one, a complete one does
+	tt.store(ttEntry{key: 1, depth: 1, value: 20})
+	if e, _ := tt.probe(1); e.value != 10 {
+		t.Errorf("a shallower result replaced the deeper one: value %d", e.value)
+	}
+	tt.store(ttEntry{key: 1, depth: 1, value: 30, complete: true})
+	if e, _ := tt.probe(1); e.value != 30 {
+		t.Errorf("a complete result didn't replace a depth-limited one: value %d", e.value)
+	}
+
+	// Keys 1 and 9 share a slot in an 8-entry table
+	tt.store(ttEntry{key: 9, depth: 1, value: 40})
+	if _, ok := tt.probe(1); ok {
+		t.Error("the overwritten position is still found")
+	}
+
+	want := TTStats{Probes: 5, Hits: 3, Stores: 3, Overwrites: 1}
+	if got := tt.Stats(); got != want {
+		t.Errorf("stats = %+v, want %+v", got, want)
+	}
+	if got := tt.Stats().HitRate(); got != 0.6 {
+		t.Errorf("hit rate = %v, want 0.6", got)
+	}
+	if got, want := tt.Stats().String(), "5 probes, 60.0% hits, 0 cutoffs"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	tt.ResetStats()
+	if got := tt.Stats(); got != (TTStats{}) || got.HitRate() != 0 {
+		t.Errorf("stats after reset = %+v", got)
+	}
+	tt.Clear()
+	if _, ok := tt.probe(9); ok {
+		t.Error("a cleared table found a position")
+	}
+}
+
+func TestTranspositionTableBounds(t *testing.T) {
+	g := game.NewGameWithSeed(2, 1)
+	const depth = 2
+	search := func(p *AIPlayer, alpha, beta int) int {
+		s := &search{ctx: context.Background(), round: g.Round}
+		return p.minimax(s, g.Clone(), depth, alpha, beta, true)
+	}
+	exact := search(NewAIPlayer(Hard, 0, WithTranspositionTable(0)), math.MinInt32, math.MaxInt32)
+
+	const far = 100000 // Far outside any real evaluation
+	for _, tc := range []struct {
+		name        string
+		entry       ttEntry
+		alpha, beta int
+		want        int
+	}{
+		{"exact", ttEntry{bound: ttExact, value: far, depth: depth}, math.MinInt32, math.MaxInt32, far},
+		{"lower bound at or above beta", ttEntry{bound: ttLower, value: far, depth: depth}, -far, far, far},
+		{"lower bound below the window", ttEntry{bound: ttLower, value: -far, depth: depth}, math.MinInt32, math.MaxInt32, exact},
+		{"upper bound at or below alpha", ttEntry{bound: ttUpper, value: -far, depth: depth}, -far, far, -far},
+		{"upper bound above the window", ttEntry{bound: ttUpper, value: far, depth: depth}, math.MinInt32, math.MaxInt32, exact},
+		{"too shallow", ttEntry{bound: ttExact, value: far, depth: depth - 1}, math.MinInt32, math.MaxInt32, exact},
+		{"complete at any depth", ttEntry{bound: ttExact, value: far, depth: 0, complete: true}, math.MinInt32, math.MaxInt32, far},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewAIPlayer(Hard, 0, WithTranspositionTable(16))
+			tc.entry.key = g.Hash()
+			p.tt.store(tc.entry)
+			if got := search(p, tc.alpha, tc.beta); got != tc.want {
+				t.Errorf("minimax = %d, want %d (searched value %d)", got, tc.want, exact)
+			}
+		})
+	}
+}
+
+func TestSearchUsesTranspositionTable(t *testing.T) {
+	g := game.NewGameWithSeed(2, 1)
+	p := NewAIPlayer(Hard, 0, WithTranspositionTable(DefaultTTSize))
+	p.ChooseMove(g, g.GetValidMoves())
+
+	stats := p.TTStats()
+	if stats.Stores == 0 || stats.Hits == 0 || stats.Cutoffs == 0 {
+		t.Errorf("the search didn't use the table: %+v", stats)
+	}
+	if stats.Hits > stats.Probes || stats.Cutoffs > stats.Hits {
+		t.Errorf("inconsistent stats: %+v", stats)
+	}
+
+	if got := NewAIPlayer(Hard, 0, WithTranspositionTable(0)).TTStats(); got != (TTStats{}) {
+		t.Errorf("an AI without a table reports %+v", got)
+	}
+}
@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// EvalWeights are the constants of the heuristic evaluation
+// Medium scores moves with the move weights; Hard's search also scores
+// positions with the state weights, and Gray Wall column choices use the
+// tiling weights. Penalties are given as positive numbers.
+type EvalWeights struct {
+	Name string `json:"name,omitempty"`
+
+	// Moves (evaluateMove)
+	CompleteLine       float64 `json:"completeLine"`       // Taking enough tiles to complete the pattern line
+	CompleteLineSize   float64 `json:"completeLineSize"`   // ... plus this much per tile in the line
+	Overflow           float64 `json:"overflow"`           // Penalty per tile that overflows to the floor
+	LineIndex          float64 `json:"lineIndex"`          // Per line index (prefer filling larger lines)
+	FloorMove          float64 `json:"floorMove"`          // Penalty for putting everything on the floor
+	EarlyFirstPlayer   float64 `json:"earlyFirstPlayer"`   // Penalty for taking the first player marker from a small center
+	RowProgress        float64 `json:"rowProgress"`        // Line feeds a wall row that already has 3+ tiles
+	ColumnProgress     float64 `json:"columnProgress"`     // Line feeds a wall column that already has 3+ tiles
+	ColorProgress      float64 `json:"colorProgress"`      // Color already has 3+ tiles on the wall
+	EarlyFirstPlayerAt int     `json:"earlyFirstPlayerAt"` // The center counts as small below this many tiles
+
+	// Positions (evaluateState)
+	Score         float64 `json:"score"`         // Per point scored
+	LineProgress  float64 `json:"lineProgress"`  // Per fraction of a pattern line filled, times the line size
+	WallPotential float64 `json:"wallPotential"` // Per point of wall bonus potential
+	OpponentScore float64 `json:"opponentScore"` // Penalty per point of the strongest opponent
+	FloorTile     float64 `json:"floorTile"`     // Penalty per tile on the floor
+
+	// Wall bonus potential (evaluateWallPotential)
+	Row4    float64 `json:"row4"` // Wall row with 4 tiles
+	Row3    float64 `json:"row3"` // Wall row with 3 tiles
+	Column4 float64 `json:"column4"`
+	Column3 float64 `json:"column3"`
+	Color4  float64 `json:"color4"` // Color with 4 tiles on the wall
+	Color3  float64 `json:"color3"`
+
+	// Gray Wall column choices (wallTilingMove)
+	TilingPoints    float64 `json:"tilingPoints"`    // Per point the placement scores
+	TilingPotential float64 `json:"tilingPotential"` // Per point of wall bonus potential left
+}
+
+// DefaultWeights returns the hand-tuned weights the AIs have always used
+func DefaultWeights() EvalWeights {
+	return EvalWeights{
+		Name: "default",
+
+		CompleteLine:       50,
+		CompleteLineSize:   10,
+		Overflow:           15,
+		LineIndex:          5,
+		FloorMove:          30,
+		EarlyFirstPlayer:   20,
+		RowProgress:        10,
+		ColumnProgress:     15,
+		ColorProgress:      20,
+		EarlyFirstPlayerAt: 5,
+
+		Score:         10,
+		LineProgress:  5,
+		WallPotential: 2,
+		OpponentScore: 8,
+		FloorTile:     5,
+
+		Row4:    10,
+		Row3:    5,
+		Column4: 20,
+		Column3: 10,
+		Color4:  25,
+		Color3:  12,
+
+		TilingPoints:    10,
+		TilingPotential: 2,
+	}
+}
+
+// presets are the named weight sets
+// Every level plays with the default weights; a preset for a level belongs
+// here only once it has weights of its own.
+var presets = map[string]func() EvalWeights{
+	"default": DefaultWeights,
+}
+
+// WeightPreset returns a named weight set
+func WeightPreset(name string) (EvalWeights, bool) {
+	preset, ok := presets[name]
+	if !ok {
+		return EvalWeights{}, false
+	}
+	return preset(), true
+}
+
+// WeightPresets lists the names of the weight presets
+func WeightPresets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadWeights reads weights from a JSON file
+// Weights the file leaves out keep their default values
+func LoadWeights(path string) (EvalWeights, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EvalWeights{}, err
+	}
+
+	w := DefaultWeights()
+	w.Name = ""
+	if err := json.Unmarshal(data, &w); err != nil {
+		return EvalWeights{}, fmt.Errorf("loading %s: %w", path, err)
+	}
+	if w.Name == "" {
+		w.Name = path
+	}
+	return w, nil
+}
+
+// Save writes the weights to a JSON file
+func (w EvalWeights) Save(path string) error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// scaled returns weight × n, rounded to the evaluation's integer scale
+func scaled(weight float64, n int) int {
+	return int(math.Round(weight * float64(n)))
+}
@@ -0,0 +1,43 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPartialWeights(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weights.json")
+	if err := os.WriteFile(path, []byte(`{"overflow": 99, "score": 2.5, "earlyFirstPlayerAt": 7}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadWeights(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultWeights()
+	want.Name = path
+	want.Overflow = 99
+	want.Score = 2.5
+	want.EarlyFirstPlayerAt = 7
+	if got != want {
+		t.Errorf("LoadWeights = %+v\nwant %+v", got, want)
+	}
+
+	// A saved set loads back unchanged, name included
+	want.Name = "tuned"
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := LoadWeights(path); err != nil || got != want {
+		t.Errorf("after Save, LoadWeights = %+v, %v\nwant %+v", got, err, want)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"overflow": "high"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadWeights(path); err == nil {
+		t.Error("a malformed weights file loaded without an error")
+	}
+}
@@ -0,0 +1,175 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/record"
+)
+
+// Default thresholds, in points lost compared with the best move
+const (
+	DefaultInaccuracy = 2.0
+	DefaultBlunder    = 5.0
+)
+
+// Severity classifies a move by how much it lost
+type Severity int
+
+const (
+	Good Severity = iota
+	Inaccuracy
+	Blunder
+	Unsearched // The search didn't finish, so the move isn't judged
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Inaccuracy:
+		return "inaccuracy"
+	case Blunder:
+		return "blunder"
+	case Unsearched:
+		return "unsearched"
+	default:
+		return "good"
+	}
+}
+
+// Config controls an analysis
+type Config struct {
+	Options    []ai.Option // Options of the Hard AI that judges the moves (think time, weights, ...)
+	Seats      []bool      // Seats whose moves are analyzed (nil = the human seats of the record)
+	Inaccuracy float64     // Points lost to count as an inaccuracy (0 = DefaultInaccuracy)
+	Blunder    float64     // Points lost to count as a blunder (0 = DefaultBlunder)
+}
+
+// MoveReview is the verdict on one recorded move
+type MoveReview struct {
+	Number   int        // Move number in the record, from 1
+	Player   int        // Seat that moved
+	Position *game.Game // Position before the move
+	Played   ai.MoveAnalysis
+	Best     ai.MoveAnalysis
+	Loss     float64 // Points the played move lost against the best one (0 if Unsearched)
+	Severity Severity
+}
+
+// Report is the analysis of a recorded game
+type Report struct {
+	Header  record.Header
+	Judge   string       // The AI that judged the moves
+	Final   *game.Game   // Last position of the game
+	Reviews []MoveReview // Analyzed moves in game order
+}
+
+// Analyze replays a recorded game and judges the moves of the chosen seats
+//
+// Each position before such a move is searched by a Hard AI; the played move's
+// value is compared with the best one and the difference is converted to points
+// with the AI's evaluation weights. Positions with a single legal move are
+// skipped, and moves whose search didn't finish a single iteration within the
+// think time are marked Unsearched rather than judged. progress, if not nil, is
+// called before each analyzed move. If ctx is cancelled, Analyze returns the
+// moves analyzed so far with ctx's error.
+func Analyze(ctx context.Context, rec *record.Record, cfg Config, progress func(number, total int)) (*Report, error) {
+	steps, err := rec.Replay()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Inaccuracy <= 0 {
+		cfg.Inaccuracy = DefaultInaccuracy
+	}
+	if cfg.Blunder <= 0 {
+		cfg.Blunder = DefaultBlunder
+	}
+	seats := cfg.Seats
+	if seats == nil {
+		seats = make([]bool, rec.Header.Players)
+		for i := range seats {
+			seats[i] = i >= len(rec.Header.AI) || rec.Header.AI[i] == ""
+		}
+	}
+
+	report := &Report{
+		Header: rec.Header,
+		Judge:  ai.NewAIPlayer(ai.Hard, 0, cfg.Options...).Identity(),
+		Final:  steps[len(steps)-1].Game,
+	}
+
+	for i := 1; i < len(steps); i++ {
+		step := steps[i]
+		if step.Player >= len(seats) || !seats[step.Player] {
+			continue
+		}
+		position := steps[i-1].Game
+		if len(position.GetValidMoves()) < 2 {
+			continue
+		}
+
+		if progress != nil {
+			progress(i, len(steps)-1)
+		}
+		judge := ai.NewAIPlayer(ai.Hard, step.Player, append(slices.Clone(cfg.Options), ai.WithSeed(rec.Header.Seed+int64(i)))...)
+		analyses := judge.Analyze(ctx, position, 0)
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+
+		review, err := judgeMove(judge, position, *step.Move, analyses, cfg)
+		if err != nil {
+			return report, fmt.Errorf("move %d: %w", i, err)
+		}
+		review.Number = i
+		review.Player = step.Player
+		report.Reviews = append(report.Reviews, review)
+	}
+
+	return report, nil
+}
+
+// judgeMove compares the played move with the best of the ranked moves
+func judgeMove(judge *ai.AIPlayer, position *game.Game, played game.Move, ranked []ai.MoveAnalysis, cfg Config) (MoveReview, error) {
+	review := MoveReview{Position: position, Best: ranked[0]}
+
+	found := false
+	for _, a := range ranked {
+		if a.Move == played {
+			review.Played = a
+			found = true
+			break
+		}
+	}
+	if !found {
+		return review, fmt.Errorf("%s is not a legal move", game.FormatMove(played))
+	}
+
+	// Heuristic scores aren't in points, so without a search there is nothing
+	// to judge the move by
+	if position.Phase == game.PhaseDrafting && !review.Best.Searched {
+		review.Severity = Unsearched
+		return review, nil
+	}
+
+	// Evaluations are in weighted points: the score weight for searched
+	// positions, the tiling points weight for Gray Wall column choices
+	weights := judge.Weights()
+	perPoint := weights.Score
+	if position.Phase == game.PhaseWallTiling {
+		perPoint = weights.TilingPoints
+	}
+	if perPoint > 0 {
+		review.Loss = float64(review.Best.Eval-review.Played.Eval) / perPoint
+	}
+
+	switch {
+	case review.Loss >= cfg.Blunder:
+		review.Severity = Blunder
+	case review.Loss >= cfg.Inaccuracy:
+		review.Severity = Inaccuracy
+	}
+	return review, nil
+}
@@ -0,0 +1,161 @@
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// PlayerSummary totals one seat's analyzed moves
+type PlayerSummary struct {
+	Player       int
+	Moves        int // Judged moves
+	Unsearched   int // Moves left unjudged because the search didn't finish
+	Inaccuracies int
+	Blunders     int
+	TotalLoss    float64 // Points lost over all analyzed moves
+}
+
+// AverageLoss returns the points lost per judged move
+func (s PlayerSummary) AverageLoss() float64 {
+	if s.Moves == 0 {
+		return 0
+	}
+	return s.TotalLoss / float64(s.Moves)
+}
+
+// Summaries totals the analyzed moves of each seat that has any
+func (r *Report) Summaries() []PlayerSummary {
+	byPlayer := make([]PlayerSummary, r.Header.Players)
+	for i := range byPlayer {
+		byPlayer[i].Player = i
+	}
+	for _, review := range r.Reviews {
+		s := &byPlayer[review.Player]
+		if review.Severity == Unsearched {
+			s.Unsearched++
+			continue
+		}
+		s.Moves++
+		s.TotalLoss += review.Loss
+		switch review.Severity {
+		case Inaccuracy:
+			s.Inaccuracies++
+		case Blunder:
+			s.Blunders++
+		}
+	}
+
+	var summaries []PlayerSummary
+	for _, s := range byPlayer {
+		if s.Moves > 0 || s.Unsearched > 0 {
+			summaries = append(summaries, s)
+		}
+	}
+	return summaries
+}
+
+// name returns a seat's name from the record header
+func (r *Report) name(player int) string {
+	if player < len(r.Header.Names) && r.Header.Names[player] != "" {
+		return r.Header.Names[player]
+	}
+	return fmt.Sprintf("Player %d", player+1)
+}
+
+// names returns the names of all seats
+func (r *Report) names() []string {
+	names := make([]string, r.Header.Players)
+	for i := range names {
+		names[i] = r.name(i)
+	}
+	return names
+}
+
+// Markdown formats the report as a Markdown document
+// Every inaccuracy and blunder gets a snapshot of the board before the move.
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+	h := r.Header
+
+	sb.WriteString("# Game analysis\n\n")
+	sb.WriteString(fmt.Sprintf("- **Game:** %s, %s, %d players, %s rules, seed %d\n",
+		h.Date.Format("2006-01-02 15:04"), strings.Join(r.names(), " vs "), h.Players, h.Variant, h.Seed))
+	if r.Final != nil && r.Final.GameOver {
+		scores := make([]string, len(r.Final.Players))
+		for i, p := range r.Final.Players {
+			scores[i] = fmt.Sprintf("%s %d", r.name(i), p.Score)
+		}
+		sb.WriteString(fmt.Sprintf("- **Result:** %s\n", strings.Join(scores, ", ")))
+	}
+	sb.WriteString(fmt.Sprintf("- **Judge:** %s\n\n", r.Judge))
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Player | Moves | Inaccuracies | Blunders | Points lost | Per move | Unsearched |\n")
+	sb.WriteString("|--------|------:|-------------:|---------:|------------:|---------:|-----------:|\n")
+	unsearched := 0
+	for _, s := range r.Summaries() {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %.1f | %.2f | %d |\n",
+			r.name(s.Player), s.Moves, s.Inaccuracies, s.Blunders, s.TotalLoss, s.AverageLoss(), s.Unsearched))
+		unsearched += s.Unsearched
+	}
+	sb.WriteString("\n")
+	if unsearched > 0 {
+		sb.WriteString(fmt.Sprintf("%d moves were not judged: the search didn't finish within the think time.\n\n", unsearched))
+	}
+
+	sb.WriteString("## Mistakes\n\n")
+	sb.WriteString("Boards are shown before the move; on the wall, uppercase letters are tiles and lowercase letters empty slots.\n\n")
+	mistakes := 0
+	for _, review := range r.Reviews {
+		if review.Severity != Inaccuracy && review.Severity != Blunder {
+			continue
+		}
+		mistakes++
+		r.writeMistake(&sb, review)
+	}
+	if mistakes == 0 {
+		sb.WriteString("No inaccuracies or blunders.\n")
+	}
+
+	return sb.String()
+}
+
+// writeMistake writes one flagged move with both candidates and a board snapshot
+func (r *Report) writeMistake(sb *strings.Builder, review MoveReview) {
+	sb.WriteString(fmt.Sprintf("### Move %d, round %d: %s played %s — %s (−%.1f points)\n\n",
+		review.Number, review.Position.Round, r.name(review.Player),
+		game.FormatMove(review.Played.Move), review.Severity, review.Loss))
+
+	sb.WriteString("| | Move | Eval | Round swing | Why |\n")
+	sb.WriteString("|-|------|-----:|------------:|-----|\n")
+	writeCandidate(sb, "Played", review.Played)
+	writeCandidate(sb, "Best", review.Best)
+	sb.WriteString("\n")
+
+	board := strings.TrimSpace(plainBoard(display.RenderGame(review.Position, r.names())))
+	sb.WriteString("```text\n" + board + "\n```\n\n")
+}
+
+// dimTile matches the letter of an empty wall slot, which the board dims
+var dimTile = regexp.MustCompile(`\x1b\[2m ?[BYRKW] ?\x1b\[0m`)
+
+// plainBoard turns a rendered board into plain text
+// Without colors, empty wall slots would read like tiles, so their letters are lowercased.
+func plainBoard(board string) string {
+	return display.StripANSI(dimTile.ReplaceAllStringFunc(board, strings.ToLower))
+}
+
+// writeCandidate writes one table row for a move
+func writeCandidate(sb *strings.Builder, label string, a ai.MoveAnalysis) {
+	reasons := make([]string, len(a.Why))
+	for i, reason := range a.Why {
+		reasons[i] = fmt.Sprintf("%+d %s", reason.Value, reason.Text)
+	}
+	sb.WriteString(fmt.Sprintf("| %s | `%s` %s | %d | %+d | %s |\n",
+		label, game.FormatMove(a.Move), a.Move.String(), a.Eval, a.Swing, strings.Join(reasons, ", ")))
+}
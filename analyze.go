@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/analysis"
+	"github.com/eddiefleurent/azul-ai/record"
+)
+
+// runAnalyze implements `azul-ai analyze FILE`: a blunder report for a recorded game
+func runAnalyze(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	thinkTime := fs.Duration("think", time.Second, "Search time per analyzed move")
+	searchName := fs.String("search", "auto", "Search: auto, paranoid, maxn")
+	ttSize := fs.Int("tt", ai.DefaultTTSize, "Transposition table entries (0 = off)")
+	weightsSpec := fs.String("weights", "", "Evaluation weights: a preset name or a JSON weights file")
+	all := fs.Bool("all", false, "Analyze the AI seats too, not only the humans")
+	inaccuracy := fs.Float64("inaccuracy", analysis.DefaultInaccuracy, "Points lost to flag an inaccuracy")
+	blunder := fs.Float64("blunder", analysis.DefaultBlunder, "Points lost to flag a blunder")
+	outPath := fs.String("o", "", "Write the Markdown report to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Println("Usage: azul-ai analyze [options] FILE")
+		fmt.Println("  Replay a game record, compare each human move with the Hard AI's best")
+		fmt.Println("  move and report the inaccuracies and blunders in Markdown")
+		fmt.Println()
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	searchMode, ok := ai.SearchModeFromString(strings.ToLower(*searchName))
+	if !ok {
+		fmt.Printf("Unknown search mode %q (use auto, paranoid or maxn)\n", *searchName)
+		os.Exit(1)
+	}
+
+	rec, err := record.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := analysis.Config{
+		Options:    []ai.Option{ai.WithSearchMode(searchMode), ai.WithThinkTime(*thinkTime), ai.WithTranspositionTable(*ttSize)},
+		Inaccuracy: *inaccuracy,
+		Blunder:    *blunder,
+	}
+	if *weightsSpec != "" {
+		weights, err := loadWeights(*weightsSpec)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.Options = append(cfg.Options, ai.WithWeights(weights))
+	}
+	if *all {
+		cfg.Seats = make([]bool, rec.Header.Players)
+		for i := range cfg.Seats {
+			cfg.Seats[i] = true
+		}
+	}
+
+	// Ctrl-C stops the analysis and reports the moves analyzed so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Progress goes to stderr so the report can be piped
+	report, err := analysis.Analyze(ctx, rec, cfg, func(number, total int) {
+		fmt.Fprintf(os.Stderr, "\rAnalyzing move %d/%d", number, total)
+	})
+	fmt.Fprintln(os.Stderr)
+	if report == nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Stopped early: %v\n", err)
+	}
+
+	if *outPath == "" {
+		fmt.Print(report.Markdown())
+		return
+	}
+	if err := os.WriteFile(*outPath, []byte(report.Markdown()), 0644); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Analysis of %d moves written to %s\n", len(report.Reviews), *outPath)
+}
@@ -2,7 +2,9 @@ package display
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/eddiefleurent/azul-ai/game"
 )
@@ -30,6 +32,14 @@ const (
 	BgWhite  = "\033[47m"
 )
 
+// ansiSequence matches ANSI escape sequences (colors, cursor movement, screen clearing)
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// StripANSI removes escape sequences, leaving plain text for files and reports
+func StripANSI(s string) string {
+	return ansiSequence.ReplaceAllString(s, "")
+}
+
 // ColorTile returns a colored block representation of a tile
 func ColorTile(t game.TileColor) string {
 	switch t {
@@ -232,16 +242,23 @@ func RenderPlayerBoard(pb *game.PlayerBoard, playerNum int, isCurrentPlayer bool
 	turnMarkerLen := 0
 	if isCurrentPlayer {
 		turnMarker = " ◄ YOUR TURN"
-		turnMarkerLen = 13 // visible characters: " ◄ YOUR TURN"
+		turnMarkerLen = 12 // visible characters: " ◄ YOUR TURN"
 	}
-	baseContent := fmt.Sprintf(" %-12s          Score: %3d", playerName, pb.Score)
-	visibleLen := len(baseContent) + turnMarkerLen
+	baseContent := fmt.Sprintf(" %-12s    Score: %3d", playerName, pb.Score)
+
+	// Score the board would have if the round ended now
+	projection := ""
+	if projected := pb.ProjectRound(false).Score; projected != pb.Score {
+		projection = fmt.Sprintf(" → %d", projected)
+	}
+
+	visibleLen := len(baseContent) + utf8.RuneCountInString(projection) + turnMarkerLen
 	// Pad to box width
 	padding := ""
 	if visibleLen < boxWidth {
 		padding = strings.Repeat(" ", boxWidth-visibleLen)
 	}
-	content := baseContent + turnMarker + padding
+	content := baseContent + Dim + projection + Reset + turnMarker + padding
 	sb.WriteString(Bold + borderColor + "│" + Reset + content + Bold + borderColor + "│" + Reset + "\n")
 	sb.WriteString(Bold + borderColor + "╰" + strings.Repeat("─", boxWidth) + "╯" + Reset + "\n")
 
@@ -280,7 +297,7 @@ func RenderPlayerBoard(pb *game.PlayerBoard, playerNum int, isCurrentPlayer bool
 
 		// Wall row - 5 tiles × 3 chars = 15 chars (same style as factory)
 		for col := 0; col < 5; col++ {
-			expectedColor := game.WallPattern[row][col]
+			expectedColor := pb.WallColor(row, col)
 			if pb.Wall[row][col] {
 				sb.WriteString(ColorTile(expectedColor))
 			} else {
@@ -337,6 +354,9 @@ func RenderGame(g *game.Game, playerNames []string) string {
 	padding := (boxWidth - len(title)) / 2
 	sb.WriteString(Bold + Cyan + "║" + Reset + strings.Repeat(" ", padding) + Bold + title + Reset + strings.Repeat(" ", boxWidth-padding-len(title)) + Bold + Cyan + "║" + Reset + "\n")
 	roundStr := fmt.Sprintf("Round %d", g.Round)
+	if g.Variant == game.GrayWall {
+		roundStr += " - Gray Wall"
+	}
 	padding = (boxWidth - len(roundStr)) / 2
 	sb.WriteString(Bold + Cyan + "║" + Reset + Dim + strings.Repeat(" ", padding) + roundStr + strings.Repeat(" ", boxWidth-padding-len(roundStr)) + Reset + Bold + Cyan + "║" + Reset + "\n")
 	sb.WriteString(Bold + Cyan + "╚" + strings.Repeat("═", boxWidth) + "╝" + Reset + "\n")
@@ -373,6 +393,11 @@ func RenderGameOver(g *game.Game, playerNames []string) string {
 
 	sb.WriteString(Bold + "Final Scores:" + Reset + "\n\n")
 
+	// Once the game is over, scores break down into rounds and end-game bonuses
+	if g.GameOver {
+		sb.WriteString(Dim + fmt.Sprintf("  %-15s %6s %5s %8s %7s %6s", "", "Rounds", "Rows", "Columns", "Colors", "Total") + Reset + "\n")
+	}
+
 	winner := g.GetWinner()
 	for i, player := range g.Players {
 		name := fmt.Sprintf("Player %d", i+1)
@@ -384,6 +409,11 @@ func RenderGameOver(g *game.Game, playerNames []string) string {
 		if i == winner {
 			marker = Green + "★ " + Reset
 		}
+		if g.GameOver {
+			b := player.EndGameBonuses()
+			sb.WriteString(fmt.Sprintf("%s%-15s %6d %5s %8s %7s %s%6d%s\n", marker, name, player.Score-b.Total(), bonus(b.Rows), bonus(b.Columns), bonus(b.Colors), Bold, player.Score, Reset))
+			continue
+		}
 		sb.WriteString(fmt.Sprintf("%s%-15s %s%3d%s points\n", marker, name, Bold, player.Score, Reset))
 	}
 
@@ -400,6 +430,34 @@ func RenderGameOver(g *game.Game, playerNames []string) string {
 	return sb.String()
 }
 
+// bonus formats an end-game bonus column, with "-" for none
+func bonus(points int) string {
+	if points == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("+%d", points)
+}
+
+// RenderHandoff clears the screen between two humans sharing the terminal
+// Azul has no hidden information, so the next screen shows the full game
+func RenderHandoff(name string) string {
+	var sb strings.Builder
+
+	sb.WriteString("\033[H\033[2J")
+	sb.WriteString("\n")
+	sb.WriteString(Bold + Cyan + "╔" + strings.Repeat("═", boxWidth) + "╗" + Reset + "\n")
+	title := name + "'s turn"
+	if len(title) > boxWidth {
+		title = title[:boxWidth]
+	}
+	padding := (boxWidth - len(title)) / 2
+	sb.WriteString(Bold + Cyan + "║" + Reset + strings.Repeat(" ", padding) + Bold + title + Reset + strings.Repeat(" ", boxWidth-padding-len(title)) + Bold + Cyan + "║" + Reset + "\n")
+	sb.WriteString(Bold + Cyan + "╚" + strings.Repeat("═", boxWidth) + "╝" + Reset + "\n")
+	sb.WriteString(fmt.Sprintf("\n  Pass the terminal to %s%s%s.\n", Bold, name, Reset))
+
+	return sb.String()
+}
+
 // ColorLegend shows what each color code means
 func ColorLegend() string {
 	var sb strings.Builder
@@ -570,7 +628,7 @@ func RenderLineSelection(pb *game.PlayerBoard, color game.TileColor, tileCount i
 		// Show wall row with the target slot highlighted (all 3-char tiles for alignment)
 		sb.WriteString("│ ")
 		for col := 0; col < 5; col++ {
-			expectedColor := game.WallPattern[lineIdx][col]
+			expectedColor := pb.WallColor(lineIdx, col)
 			if pb.Wall[lineIdx][col] {
 				// Already placed on wall
 				sb.WriteString(ColorTile(expectedColor))
@@ -606,6 +664,32 @@ func RenderLineSelection(pb *game.PlayerBoard, color game.TileColor, tileCount i
 	return sb.String()
 }
 
+// RenderWallTilingSelection shows the Gray Wall columns a full pattern line can be placed in
+func RenderWallTilingSelection(pb *game.PlayerBoard, row int, color game.TileColor, columns []int) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("\n"+Bold+"Wall tiling: "+Reset+"Line %d is full. Place its %s tile in which column?\n\n", row+1, color.FullName()))
+
+	for i, col := range columns {
+		sb.WriteString(fmt.Sprintf("  %s[%d]%s ", Cyan+Bold, i+1, Reset))
+
+		// Show the wall row with the candidate slot filled
+		sb.WriteString("│ ")
+		for c := 0; c < 5; c++ {
+			if c == col {
+				sb.WriteString(ColorTile(color))
+			} else if pb.Wall[row][c] {
+				sb.WriteString(ColorTile(pb.WallColor(row, c)))
+			} else {
+				sb.WriteString(DimTile(game.NoTile))
+			}
+		}
+		sb.WriteString(fmt.Sprintf(" │ column %d "+Green+"+%d"+Reset+"\n", col+1, pb.ScoreWallTile(row, col)))
+	}
+
+	return sb.String()
+}
+
 // RenderBoardPreview shows the player's board with a preview of tiles being placed
 func RenderBoardPreview(pb *game.PlayerBoard, color game.TileColor, tileCount int, targetLine int) string {
 	var sb strings.Builder
@@ -657,7 +741,7 @@ func RenderBoardPreview(pb *game.PlayerBoard, color game.TileColor, tileCount in
 		// Wall row - 5 tiles × 3 chars = 15 chars (same style as factory)
 		willComplete := isPreviewRow && previewFilled == pl.Size
 		for col := 0; col < 5; col++ {
-			expectedColor := game.WallPattern[row][col]
+			expectedColor := pb.WallColor(row, col)
 			if pb.Wall[row][col] {
 				sb.WriteString(ColorTile(expectedColor))
 			} else if willComplete && expectedColor == color {
@@ -0,0 +1,96 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// RoundSummary is the scoring of one round, gathered from the game's events
+type RoundSummary struct {
+	Round  int
+	Tiles  []game.TilePlacedOnWall // In the order they were tiled
+	Floors []game.FloorPenalty
+	Scores []int // Scores once the round is scored
+}
+
+// RenderRoundSummary shows how each player's score changed in a round:
+// every tile moved to the wall with its row and column points, the floor
+// penalty of each slot, and the running total
+func RenderRoundSummary(s *RoundSummary, playerNames []string) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(Bold + Cyan + "╔" + strings.Repeat("═", boxWidth) + "╗" + Reset + "\n")
+	title := fmt.Sprintf("ROUND %d SCORING", s.Round)
+	padding := (boxWidth - len(title)) / 2
+	sb.WriteString(Bold + Cyan + "║" + Reset + strings.Repeat(" ", padding) + Bold + title + Reset + strings.Repeat(" ", boxWidth-padding-len(title)) + Bold + Cyan + "║" + Reset + "\n")
+	sb.WriteString(Bold + Cyan + "╚" + strings.Repeat("═", boxWidth) + "╝" + Reset + "\n")
+
+	for i, final := range s.Scores {
+		name := fmt.Sprintf("Player %d", i+1)
+		if i < len(playerNames) && playerNames[i] != "" {
+			name = playerNames[i]
+		}
+
+		var tiles []game.TilePlacedOnWall
+		score := final
+		for _, t := range s.Tiles {
+			if t.Player == i {
+				tiles = append(tiles, t)
+				score -= t.Points
+			}
+		}
+		var floor *game.FloorPenalty
+		for j := range s.Floors {
+			if s.Floors[j].Player == i {
+				floor = &s.Floors[j]
+				score -= floor.Points
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("\n%s%s%s: %d → %s%d%s (%+d)\n", Bold, name, Reset, score, Bold, final, Reset, final-score))
+		if len(tiles) == 0 && floor == nil {
+			sb.WriteString(Dim + "    Nothing scored" + Reset + "\n")
+			continue
+		}
+		sb.WriteString(Dim + fmt.Sprintf("    %-16s %6s %6s", "", "Points", "Total") + Reset + "\n")
+
+		for _, t := range tiles {
+			score += t.Points
+			place := fmt.Sprintf("Row %d, col %d", t.Row+1, t.Column+1)
+			sb.WriteString(fmt.Sprintf("    %s %-14s %6s %6d   %s%s%s\n", ColorTileSmall(t.Color), place, fmt.Sprintf("%+d", t.Points), score, Dim, links(t), Reset))
+		}
+
+		if floor != nil {
+			score += floor.Points
+			var slots []string
+			lost := 0
+			for j, tile := range floor.Tiles {
+				slots = append(slots, fmt.Sprintf("%s%s%d%s", ColorTileSmall(tile), Red, floor.Slots[j], Reset))
+				lost += floor.Slots[j]
+			}
+			note := ""
+			if floor.Points != lost {
+				note = Dim + "  (scores stop at 0)" + Reset
+			}
+			sb.WriteString(fmt.Sprintf("    %-16s %s%6d%s %6d   %s%s\n", "Floor", Red, floor.Points, Reset, score, strings.Join(slots, " "), note))
+		}
+	}
+
+	return sb.String()
+}
+
+// links explains a wall tile's points by the tiles it links up with
+func links(t game.TilePlacedOnWall) string {
+	switch {
+	case t.RowPoints > 0 && t.ColumnPoints > 0:
+		return fmt.Sprintf("%d in row + %d in column", t.RowPoints, t.ColumnPoints)
+	case t.RowPoints > 0:
+		return fmt.Sprintf("%d in row", t.RowPoints)
+	case t.ColumnPoints > 0:
+		return fmt.Sprintf("%d in column", t.ColumnPoints)
+	}
+	return "no neighbors"
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/engine"
+	"github.com/eddiefleurent/azul-ai/tournament"
+)
+
+// runEngine implements `azul-ai engine`: a built-in AI speaking the engine protocol on stdin/stdout
+func runEngine(args []string) {
+	fs := flag.NewFlagSet("engine", flag.ExitOnError)
+	aiDifficulty := fs.String("ai", "hard", "AI difficulty: easy, medium, hard, mcts")
+	searchName := fs.String("search", "auto", "Hard AI search: auto, paranoid, maxn")
+	ttSize := fs.Int("tt", ai.DefaultTTSize, "Hard AI transposition table entries (0 = off)")
+	mctsIterations := fs.Int("mcts-iterations", ai.DefaultMCTSConfig().Iterations, "MCTS playouts per move (0 = time limit only)")
+	weightsSpec := fs.String("weights", "", "Evaluation weights: a preset name or a JSON weights file")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: azul-ai engine [options]")
+		fmt.Fprintln(os.Stderr, "  Play as an engine: read protocol commands on stdin, answer on stdout")
+		fmt.Fprintln(os.Stderr)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	difficulty, ok := ai.DifficultyFromString(strings.ToLower(*aiDifficulty))
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown AI level %q (use easy, medium, hard or mcts)\n", *aiDifficulty)
+		os.Exit(1)
+	}
+	searchMode, ok := ai.SearchModeFromString(strings.ToLower(*searchName))
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown search mode %q (use auto, paranoid or maxn)\n", *searchName)
+		os.Exit(1)
+	}
+
+	// go movetime limits the thinking time, so MCTS has no time limit of its own
+	mctsConfig := ai.DefaultMCTSConfig()
+	mctsConfig.Iterations = *mctsIterations
+	mctsConfig.TimeLimit = 0
+	opts := []ai.Option{ai.WithMCTS(mctsConfig), ai.WithSearchMode(searchMode), ai.WithTranspositionTable(*ttSize)}
+	if *weightsSpec != "" {
+		weights, err := loadWeights(*weightsSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, ai.WithWeights(weights))
+	}
+
+	name := "azul-ai " + ai.NewAIPlayer(difficulty, 0, append(slices.Clone(opts), ai.WithTranspositionTable(0))...).Identity()
+	newPlayer := func(seat int) ai.Player {
+		return ai.NewAIPlayer(difficulty, seat, opts...)
+	}
+
+	if err := engine.Serve(os.Stdin, os.Stdout, name, newPlayer); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// engineRatingID returns the rating identity of an external engine
+func engineRatingID(name string) string {
+	return "engine:" + name
+}
+
+// startEngine runs an external engine given as a command line
+// moveTime, if set, replaces the engine's default thinking time per move
+func startEngine(command []string, moveTime time.Duration) (*engine.Engine, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("no engine command given")
+	}
+	e, err := engine.Start(command[0], command[1:]...)
+	if err != nil {
+		return nil, err
+	}
+	if moveTime > 0 {
+		e.MoveTime = moveTime
+	}
+	return e, nil
+}
+
+// engineEntrant returns a tournament entrant that runs an external engine for every game
+// The engine is started once up front to check that it works and learn its name
+func engineEntrant(command []string, moveTime time.Duration) (tournament.Entrant, error) {
+	e, err := startEngine(command, moveTime)
+	if err != nil {
+		return tournament.Entrant{}, err
+	}
+	name := e.Name()
+	e.Close()
+
+	return tournament.Entrant{
+		Name: name,
+		ID:   engineRatingID(name),
+		NewPlayer: func(seat int, seed int64) (ai.Player, error) {
+			return startEngine(command, moveTime)
+		},
+	}, nil
+}
@@ -0,0 +1,271 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// Timeouts for an engine's replies
+const (
+	DefaultMoveTime = time.Second
+	startupTimeout  = 10 * time.Second
+	graceTime       = 2 * time.Second // Allowed beyond movetime before bestmove counts as late
+)
+
+// Engine is an ai.Player that asks a subprocess speaking the engine protocol for its moves
+type Engine struct {
+	// MoveTime is the thinking time sent with every go command
+	MoveTime time.Duration
+
+	cmd   *exec.Cmd // nil when connected over plain pipes (see newEngine)
+	stdin io.WriteCloser
+	lines chan string // Engine output, closed when the engine exits
+	name  string
+	rng   *rand.Rand
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Start runs an engine and completes the protocol handshake
+// The engine's stderr is passed through to ours.
+func Start(path string, args ...string) (*Engine, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	e := newEngine(path, stdin, stdout)
+	e.cmd = cmd
+	if err := e.handshake(); err != nil {
+		e.Close()
+		return nil, fmt.Errorf("engine %s: %w", path, err)
+	}
+	return e, nil
+}
+
+// newEngine talks to an engine that reads commands from stdin and writes replies to stdout
+// It starts reading the replies; the caller runs the handshake.
+func newEngine(name string, stdin io.WriteCloser, stdout io.Reader) *Engine {
+	e := &Engine{
+		MoveTime: DefaultMoveTime,
+		stdin:    stdin,
+		lines:    make(chan string, 64),
+		name:     name,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	go func() {
+		defer close(e.lines)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			e.lines <- scanner.Text()
+		}
+	}()
+	return e
+}
+
+// handshake sends aei and reads the engine's id until aeiok
+func (e *Engine) handshake() error {
+	if err := e.send(CmdHello); err != nil {
+		return err
+	}
+
+	timeout := time.After(startupTimeout)
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return fmt.Errorf("exited during handshake")
+			}
+			fields := strings.Fields(line)
+			switch {
+			case len(fields) >= 3 && fields[0] == ReplyID && fields[1] == "name":
+				e.name = strings.Join(fields[2:], " ")
+			case len(fields) == 1 && fields[0] == ReplyHelloOK:
+				return nil
+			}
+		case <-timeout:
+			return fmt.Errorf("no %s within %s", ReplyHelloOK, startupTimeout)
+		}
+	}
+}
+
+// Name returns the name the engine gave in its handshake
+func (e *Engine) Name() string {
+	return e.name
+}
+
+// ChooseMove asks the engine for a move
+func (e *Engine) ChooseMove(g *game.Game, moves []game.Move) game.Move {
+	return e.ChooseMoveContext(context.Background(), g, moves)
+}
+
+// ChooseMoveContext asks the engine for a move, sending stop if ctx ends first
+// If the engine fails, answers late or answers with an illegal move, the first
+// legal move is played instead.
+func (e *Engine) ChooseMoveContext(ctx context.Context, g *game.Game, moves []game.Move) game.Move {
+	if len(moves) == 0 {
+		return game.Move{}
+	}
+
+	// The engine sees the position with the bag reshuffled and reseeded
+	doc, err := json.Marshal(g.Determinize(e.rng))
+	if err != nil {
+		return moves[0]
+	}
+	legal := make([]string, len(moves))
+	for i, move := range moves {
+		legal[i] = game.FormatMove(move)
+	}
+
+	moveTime := e.MoveTime
+	if deadline, ok := ctx.Deadline(); ok {
+		moveTime = min(moveTime, time.Until(deadline))
+	}
+	moveTime = max(moveTime, time.Millisecond)
+
+	e.drain()
+	if e.send(CmdPosition+" "+string(doc)) != nil ||
+		e.send(CmdLegalMoves+" "+strings.Join(legal, " ")) != nil ||
+		e.send(fmt.Sprintf("%s movetime %d", CmdGo, moveTime.Milliseconds())) != nil {
+		return moves[0]
+	}
+
+	text, ok := e.awaitBestMove(ctx, moveTime+graceTime)
+	if !ok {
+		return moves[0]
+	}
+	move, err := game.ParseMove(text)
+	if err != nil {
+		return moves[0]
+	}
+	for _, m := range moves {
+		if m == move {
+			return m
+		}
+	}
+	return moves[0]
+}
+
+// awaitBestMove waits for the engine's bestmove
+// When ctx ends or the timeout passes, it sends stop and waits a little longer.
+func (e *Engine) awaitBestMove(ctx context.Context, timeout time.Duration) (string, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	stopped := false
+
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return "", false
+			}
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == ReplyBestMove {
+				return fields[1], true
+			}
+		case <-ctx.Done():
+			if stopped {
+				return "", false
+			}
+			stopped = true
+			e.send(CmdStop)
+			ctx = context.Background() // Don't select on the finished ctx again
+			timer.Reset(graceTime)
+		case <-timer.C:
+			if stopped {
+				return "", false
+			}
+			stopped = true
+			e.send(CmdStop)
+			timer.Reset(graceTime)
+		}
+	}
+}
+
+// drain discards output left over from an earlier move (e.g. a late bestmove)
+func (e *Engine) drain() {
+	for {
+		select {
+		case _, ok := <-e.lines:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// NewGame tells the engine that a new game starts
+func (e *Engine) NewGame() error {
+	return e.send(CmdNewGame)
+}
+
+// send writes one command line
+func (e *Engine) send(line string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return fmt.Errorf("engine closed")
+	}
+	_, err := io.WriteString(e.stdin, line+"\n")
+	return err
+}
+
+// Close sends quit and waits for the engine to exit, killing it if it doesn't
+func (e *Engine) Close() error {
+	e.send(CmdQuit)
+
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	e.stdin.Close()
+	e.mu.Unlock()
+
+	exited := make(chan error, 1)
+	go func() {
+		for range e.lines {
+		}
+		if e.cmd == nil {
+			exited <- nil
+			return
+		}
+		exited <- e.cmd.Wait()
+	}()
+
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(graceTime):
+		if e.cmd == nil {
+			return fmt.Errorf("engine %s didn't exit", e.name)
+		}
+		e.cmd.Process.Kill()
+		return <-exited
+	}
+}
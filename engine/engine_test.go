@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// pipeEngine connects an Engine to serve over in-memory pipes and runs the handshake
+func pipeEngine(t *testing.T, serve func(in io.Reader, out io.Writer)) *Engine {
+	t.Helper()
+	cmdR, cmdW := io.Pipe()
+	outR, outW := io.Pipe()
+	go func() {
+		serve(cmdR, outW)
+		cmdR.Close()
+		outW.Close()
+	}()
+
+	e := newEngine("pipe", cmdW, outR)
+	if err := e.handshake(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := e.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return e
+}
+
+// fakeEngine answers every go with the given bestmove text
+func fakeEngine(reply string) func(in io.Reader, out io.Writer) {
+	return func(in io.Reader, out io.Writer) {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+		for scanner.Scan() {
+			switch cmd, _, _ := strings.Cut(scanner.Text(), " "); cmd {
+			case CmdHello:
+				fmt.Fprintf(out, "%s name fake\n%s\n", ReplyID, ReplyHelloOK)
+			case CmdGo:
+				fmt.Fprintf(out, "%s %s\n", ReplyBestMove, reply)
+			case CmdQuit:
+				return
+			}
+		}
+	}
+}
+
+func TestServeOverPipe(t *testing.T) {
+	newPlayer := func(seat int) ai.Player { return ai.NewAIPlayer(ai.Medium, seat, ai.WithSeed(1)) }
+	e := pipeEngine(t, func(in io.Reader, out io.Writer) {
+		Serve(in, out, "pipe medium", newPlayer)
+	})
+	if e.Name() != "pipe medium" {
+		t.Errorf("name = %q, want the one from the handshake", e.Name())
+	}
+
+	g := game.NewGameWithSeed(2, 1)
+	moves := g.GetValidMoves()
+	want := newPlayer(0).ChooseMove(g, moves)
+	if got := e.ChooseMove(g, moves); got != want {
+		t.Errorf("bestmove %s, want %s as Medium plays it", game.FormatMove(got), game.FormatMove(want))
+	}
+
+	// The engine chooses among the legal moves it is sent
+	others := slices.DeleteFunc(slices.Clone(moves), func(m game.Move) bool { return m == want })
+	if got := e.ChooseMove(g, others); got == want || !slices.Contains(others, got) {
+		t.Errorf("bestmove %s isn't one of the legal moves sent", game.FormatMove(got))
+	}
+
+	// Next seat, next position
+	if err := g.ApplyMove(want); err != nil {
+		t.Fatal(err)
+	}
+	moves = g.GetValidMoves()
+	if got := e.ChooseMove(g, moves); !slices.Contains(moves, got) {
+		t.Errorf("seat 2: bestmove %s is not a legal move", game.FormatMove(got))
+	}
+}
+
+func TestBadBestMoveFallsBack(t *testing.T) {
+	g := game.NewGameWithSeed(2, 1)
+	moves := g.GetValidMoves()
+	legal := moves[1:] // moves[0] is legal on the board but not offered
+
+	for _, tc := range []struct {
+		name  string
+		reply string
+	}{
+		{"unparsable", "xyz"},
+		{"none", "none"},
+		{"not offered", game.FormatMove(moves[0])},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := pipeEngine(t, fakeEngine(tc.reply))
+			if got := e.ChooseMove(g, legal); got != legal[0] {
+				t.Errorf("bestmove %q: played %s, want the first legal move %s", tc.reply, game.FormatMove(got), game.FormatMove(legal[0]))
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package engine speaks a line-based engine protocol, modelled on UCI, so that
+// bots running in other processes can play against the built-in AIs.
+//
+// The host writes commands to the engine's stdin, one per line, and reads
+// replies from its stdout:
+//
+//	aei                      Start of the session. The engine answers with
+//	                         "id name NAME", optionally "id author AUTHOR",
+//	                         then "aeiok".
+//	isready                  The engine answers "readyok" once it is ready.
+//	newgame                  A new game starts; forget anything learned.
+//	position JSON            Set the position: a saved game document
+//	                         (game.SaveFile format) on a single line. Its bag
+//	                         is reshuffled and its seed and random state
+//	                         replaced, so it never reveals the real draws.
+//	legalmoves M1 M2 ...     The legal moves in the position, in notation
+//	                         (3B2, CY-, 2B@3). Sent after every position, so
+//	                         engines needn't implement the rules.
+//	go [movetime MS]         Think about the position for at most MS
+//	                         milliseconds (forever, or until stop, without
+//	                         movetime), then answer "bestmove MOVE".
+//	stop                     Answer bestmove now.
+//	quit                     Exit.
+//
+// While thinking, an engine may send "info ..." lines (e.g. "info depth 3
+// score 120" or "info string text"); hosts ignore them. Unknown commands
+// should be ignored too. A bestmove that isn't one of the legal moves, or that
+// comes too late, forfeits the choice: the host plays the first legal move.
+package engine
+
+// Commands sent by the host
+const (
+	CmdHello      = "aei"
+	CmdIsReady    = "isready"
+	CmdNewGame    = "newgame"
+	CmdPosition   = "position"
+	CmdLegalMoves = "legalmoves"
+	CmdGo         = "go"
+	CmdStop       = "stop"
+	CmdQuit       = "quit"
+)
+
+// Replies sent by the engine
+const (
+	ReplyID       = "id"
+	ReplyHelloOK  = "aeiok"
+	ReplyReadyOK  = "readyok"
+	ReplyInfo     = "info"
+	ReplyBestMove = "bestmove"
+)
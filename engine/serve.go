@@ -0,0 +1,168 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// Serve runs an engine session on in and out until quit or the end of input
+// newPlayer creates the AI for a seat; each seat gets its own, since AIs
+// evaluate positions from their seat's point of view.
+func Serve(in io.Reader, out io.Writer, name string, newPlayer func(seat int) ai.Player) error {
+	e := &served{out: out, name: name, newPlayer: newPlayer, players: make(map[int]ai.Player)}
+	defer e.stop()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		cmd, args, _ := strings.Cut(line, " ")
+
+		switch cmd {
+		case "":
+		case CmdHello:
+			e.send("%s name %s", ReplyID, name)
+			e.send("%s author azul-ai", ReplyID)
+			e.send(ReplyHelloOK)
+		case CmdIsReady:
+			e.send(ReplyReadyOK)
+		case CmdNewGame:
+			e.stop()
+			e.players = make(map[int]ai.Player)
+			e.game, e.legal = nil, nil
+		case CmdPosition:
+			e.stop()
+			if err := e.setPosition(args); err != nil {
+				e.send("%s string bad position: %v", ReplyInfo, err)
+			}
+		case CmdLegalMoves:
+			e.stop()
+			if err := e.setLegalMoves(args); err != nil {
+				e.send("%s string bad legal moves: %v", ReplyInfo, err)
+			}
+		case CmdGo:
+			e.stop()
+			e.think(args)
+		case CmdStop:
+			e.stop()
+		case CmdQuit:
+			return nil
+		default:
+			e.send("%s string unknown command %q", ReplyInfo, cmd)
+		}
+	}
+	return scanner.Err()
+}
+
+// served is the state of an engine session
+type served struct {
+	out       io.Writer
+	name      string
+	newPlayer func(seat int) ai.Player
+	players   map[int]ai.Player
+
+	game  *game.Game
+	legal []game.Move
+
+	writeMu sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{} // Closed when the running search has answered
+}
+
+// send writes one reply line
+func (e *served) send(format string, args ...any) {
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+	fmt.Fprintf(e.out, format+"\n", args...)
+}
+
+// setPosition parses the game document of a position command
+func (e *served) setPosition(doc string) error {
+	var g game.Game
+	if err := json.Unmarshal([]byte(doc), &g); err != nil {
+		return err
+	}
+	e.game = &g
+	e.legal = g.GetValidMoves()
+	return nil
+}
+
+// setLegalMoves restricts the moves to choose from to those given
+func (e *served) setLegalMoves(list string) error {
+	var moves []game.Move
+	for _, text := range strings.Fields(list) {
+		move, err := game.ParseMove(text)
+		if err != nil {
+			return err
+		}
+		moves = append(moves, move)
+	}
+	e.legal = moves
+	return nil
+}
+
+// think starts a search for the go command with the given arguments
+func (e *served) think(args string) {
+	if e.game == nil || len(e.legal) == 0 {
+		e.send("%s string no position to search", ReplyInfo)
+		e.send("%s none", ReplyBestMove)
+		return
+	}
+
+	var limit time.Duration
+	fields := strings.Fields(args)
+	for i := 0; i < len(fields); i++ {
+		if fields[i] == "movetime" && i+1 < len(fields) {
+			if ms, err := strconv.Atoi(fields[i+1]); err == nil && ms > 0 {
+				limit = time.Duration(ms) * time.Millisecond
+			}
+			i++
+		}
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if limit > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), limit)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	e.cancel = cancel
+	e.done = make(chan struct{})
+
+	seat := e.game.CurrentPlayer
+	p, ok := e.players[seat]
+	if !ok {
+		p = e.newPlayer(seat)
+		e.players[seat] = p
+	}
+	g := e.game.Clone()
+	moves := append([]game.Move(nil), e.legal...)
+	done := e.done
+
+	go func() {
+		defer close(done)
+		move := ai.ChooseMove(ctx, p, g, moves)
+		e.send("%s %s", ReplyBestMove, game.FormatMove(move))
+	}()
+}
+
+// stop ends the running search, if any, once it has answered
+func (e *served) stop() {
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+	e.cancel, e.done = nil, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// eventLog writes a game's events to a file, one JSON object per line:
+// {"event":"RoundEnded","data":{"round":1,"scores":[7,4]}}
+// game.DecodeEvent turns a line's event and data back into a game.Event.
+type eventLog struct {
+	f   *os.File
+	enc *json.Encoder
+	err error // First write error; nothing is logged after it
+}
+
+// openEventLog creates (or truncates) an event log file
+func openEventLog(path string) (*eventLog, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &eventLog{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// OnEvent writes one event; events are logged as they happen, so the file
+// is complete even if the game is quit
+// A write error is reported once and stops the log, rather than leaving gaps in it.
+func (l *eventLog) OnEvent(e game.Event) {
+	if l.err != nil {
+		return
+	}
+	l.err = l.enc.Encode(struct {
+		Event string     `json:"event"`
+		Data  game.Event `json:"data"`
+	}{game.EventName(e), e})
+	if l.err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing event log %s, logging stopped: %v\n", l.f.Name(), l.err)
+	}
+}
+
+// Close closes the log file
+func (l *eventLog) Close() error {
+	return l.f.Close()
+}
@@ -4,21 +4,51 @@ import (
 	"math/rand"
 )
 
-// Bag holds tiles to be drawn and discarded tiles
+// bagSource is a splitmix64 random source whose state can be copied and saved,
+// so clones and saved games shuffle exactly like the original
+type bagSource struct {
+	state uint64
+}
+
+func (s *bagSource) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+func (s *bagSource) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *bagSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Bag holds the tiles to be drawn
+// Discarded tiles wait in the Lid until the bag runs out
 type Bag struct {
-	tiles    []TileColor
-	discards []TileColor
-	rng      *rand.Rand
-	seed     int64 // Original seed for cloning
+	tiles []TileColor
+	src   *bagSource
+	rng   *rand.Rand
+	seed  int64 // Original seed, kept for reference
+}
+
+// newBagSource creates the RNG pair used by a bag
+func newBagSource(state uint64) (*bagSource, *rand.Rand) {
+	src := &bagSource{state: state}
+	return src, rand.New(src)
 }
 
 // NewBag creates a bag with 20 tiles of each color (100 total)
 func NewBag(seed int64) *Bag {
+	src, rng := newBagSource(uint64(seed))
 	b := &Bag{
-		tiles:    make([]TileColor, 0, 100),
-		discards: make([]TileColor, 0, 100),
-		rng:      rand.New(rand.NewSource(seed)),
-		seed:     seed,
+		tiles: make([]TileColor, 0, 100),
+		src:   src,
+		rng:   rng,
+		seed:  seed,
 	}
 
 	// Add 20 of each color
@@ -39,56 +69,54 @@ func (b *Bag) Shuffle() {
 	})
 }
 
-// Draw removes and returns n tiles from the bag
-// If bag is empty, refills from discards first
+// Reshuffle reseeds the bag and shuffles the tiles left in it
+// Game.Determinize uses this to sample an unknown bag order. The original seed
+// is replaced too, or it would still give away the real draw order.
+func (b *Bag) Reshuffle(seed int64) {
+	b.src.Seed(seed)
+	b.seed = seed
+	b.Shuffle()
+}
+
+// Draw removes and returns up to n tiles from the bag
+// It returns fewer than n when the bag runs out; Game.drawTiles then refills
+// the bag from the lid
 func (b *Bag) Draw(n int) []TileColor {
-	drawn := make([]TileColor, 0, n)
-
-	for i := 0; i < n; i++ {
-		if len(b.tiles) == 0 {
-			b.RefillFromDiscards()
-			if len(b.tiles) == 0 {
-				break // No more tiles anywhere
-			}
-		}
-		drawn = append(drawn, b.tiles[len(b.tiles)-1])
+	n = min(n, len(b.tiles))
+	drawn := make([]TileColor, n)
+	for i := range drawn {
+		drawn[i] = b.tiles[len(b.tiles)-1]
 		b.tiles = b.tiles[:len(b.tiles)-1]
 	}
-
 	return drawn
 }
 
-// RefillFromDiscards moves all discards back to the bag and shuffles
-func (b *Bag) RefillFromDiscards() {
-	b.tiles = append(b.tiles, b.discards...)
-	b.discards = b.discards[:0]
+// Refill puts tiles back in the bag and shuffles it
+func (b *Bag) Refill(tiles []TileColor) {
+	b.tiles = append(b.tiles, tiles...)
 	b.Shuffle()
 }
 
-// Discard adds tiles to the discard pile
-func (b *Bag) Discard(tiles []TileColor) {
-	b.discards = append(b.discards, tiles...)
-}
-
-// TilesRemaining returns count of tiles in bag (not discards)
+// TilesRemaining returns count of tiles in bag
 func (b *Bag) TilesRemaining() int {
 	return len(b.tiles)
 }
 
-// TotalTilesInPlay returns tiles in bag + discards
-func (b *Bag) TotalTilesInPlay() int {
-	return len(b.tiles) + len(b.discards)
+// Seed returns the seed the bag was created with
+func (b *Bag) Seed() int64 {
+	return b.seed
 }
 
 // Clone creates a deep copy of the bag (for AI simulation)
+// The copy continues the same random sequence as the original
 func (b *Bag) Clone() *Bag {
+	src, rng := newBagSource(b.src.state)
 	newBag := &Bag{
-		tiles:    make([]TileColor, len(b.tiles)),
-		discards: make([]TileColor, len(b.discards)),
-		rng:      rand.New(rand.NewSource(b.seed)),
-		seed:     b.seed,
+		tiles: make([]TileColor, len(b.tiles)),
+		src:   src,
+		rng:   rng,
+		seed:  b.seed,
 	}
 	copy(newBag.tiles, b.tiles)
-	copy(newBag.discards, b.discards)
 	return newBag
 }
@@ -0,0 +1,184 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Event is something that happened in a game
+// Subscribers receive events as the game applies them; switch on the concrete
+// type to handle the ones you need. A move sends MoveApplied first, then its
+// consequences in order: TilesToCenter or FirstPlayerTaken, FloorOverflow and,
+// if it ends the round, TilePlacedOnWall for every tile moved to a wall,
+// FloorPenalty, RoundEnded and then GameEnded or the next FactoriesFilled.
+// Undo sends MoveUndone with the restored scores; Redo applies the move again
+// and sends its events again.
+type Event interface {
+	event()
+}
+
+// Subscriber receives the events of a game
+type Subscriber interface {
+	OnEvent(e Event)
+}
+
+// SubscriberFunc adapts a function to a Subscriber
+type SubscriberFunc func(e Event)
+
+// OnEvent calls f(e)
+func (f SubscriberFunc) OnEvent(e Event) {
+	f(e)
+}
+
+// WithSubscriber subscribes s to the game's events from the first round on
+func WithSubscriber(s Subscriber) Option {
+	return func(g *Game) {
+		g.Subscribe(s)
+	}
+}
+
+// Subscribe adds a subscriber to the game's events
+// Clones have no subscribers, so AI search never emits events.
+func (g *Game) Subscribe(s Subscriber) {
+	g.subscribers = append(g.subscribers, s)
+}
+
+// emit sends an event to every subscriber
+func (g *Game) emit(e Event) {
+	for _, s := range g.subscribers {
+		s.OnEvent(e)
+	}
+}
+
+// MoveApplied is sent when a player's move is applied, before its consequences
+type MoveApplied struct {
+	Player int  `json:"player"`
+	Move   Move `json:"move"`
+	Tiles  int  `json:"tiles"` // Tiles taken by a drafting move
+}
+
+// TilesToCenter is sent when the tiles left on a drafted factory move to the center
+type TilesToCenter struct {
+	Factory int         `json:"factory"`
+	Tiles   []TileColor `json:"tiles"`
+}
+
+// FirstPlayerTaken is sent when a player takes the first player marker from the center
+type FirstPlayerTaken struct {
+	Player int `json:"player"`
+}
+
+// FloorOverflow is sent when tiles fall past a full floor line into the lid
+type FloorOverflow struct {
+	Player int         `json:"player"`
+	Tiles  []TileColor `json:"tiles"`
+}
+
+// TilePlacedOnWall is sent for every tile moved from a pattern line to the wall
+type TilePlacedOnWall struct {
+	Player       int       `json:"player"`
+	Row          int       `json:"row"`
+	Column       int       `json:"column"`
+	Color        TileColor `json:"color"`
+	Points       int       `json:"points"`
+	RowPoints    int       `json:"rowPoints"`    // Tiles linked in the row, itself included (0 if none)
+	ColumnPoints int       `json:"columnPoints"` // Tiles linked in the column, itself included (0 if none)
+}
+
+// FloorPenalty is sent when a player's floor line is scored
+type FloorPenalty struct {
+	Player int         `json:"player"`
+	Tiles  []TileColor `json:"tiles"`
+	Slots  []int       `json:"slots"`  // Penalty of each tile's slot
+	Points int         `json:"points"` // Points lost (zero or negative), limited so the score stays at 0 or more
+}
+
+// RoundEnded is sent once a round's scoring is done
+type RoundEnded struct {
+	Round  int   `json:"round"`
+	Scores []int `json:"scores"`
+}
+
+// Bonuses are a player's end-game bonus points
+type Bonuses struct {
+	Rows    int `json:"rows"`    // +2 per complete row
+	Columns int `json:"columns"` // +7 per complete column
+	Colors  int `json:"colors"`  // +10 per color placed 5 times
+}
+
+// Total returns the sum of the bonuses
+func (b Bonuses) Total() int {
+	return b.Rows + b.Columns + b.Colors
+}
+
+// GameEnded is sent after the end-game bonuses are scored
+type GameEnded struct {
+	Bonuses []Bonuses `json:"bonuses"`
+	Scores  []int     `json:"scores"` // Final scores, bonuses included
+	Winner  int       `json:"winner"` // -1 for a shared victory
+}
+
+// FactoriesFilled is sent when the factories are filled for a round
+type FactoriesFilled struct {
+	Round     int           `json:"round"`
+	Factories [][]TileColor `json:"factories"` // Tiles of each factory
+	Missing   int           `json:"missing"`   // Tiles short because the bag and the lid both ran out
+}
+
+// BagRefilled is sent when the empty bag is refilled from the lid
+type BagRefilled struct {
+	Tiles int `json:"tiles"` // Tiles poured from the lid into the bag
+}
+
+// MoveUndone is sent when Undo takes back a move
+// Everything the move's events did is undone: the game is back in Round,
+// with Scores, as it was before the move.
+type MoveUndone struct {
+	Player int   `json:"player"`
+	Move   Move  `json:"move"`
+	Round  int   `json:"round"`
+	Scores []int `json:"scores"`
+}
+
+func (MoveApplied) event()      {}
+func (TilesToCenter) event()    {}
+func (FirstPlayerTaken) event() {}
+func (FloorOverflow) event()    {}
+func (TilePlacedOnWall) event() {}
+func (FloorPenalty) event()     {}
+func (RoundEnded) event()       {}
+func (GameEnded) event()        {}
+func (FactoriesFilled) event()  {}
+func (BagRefilled) event()      {}
+func (MoveUndone) event()       {}
+
+// eventTypes lists every event by name, for DecodeEvent
+var eventTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, e := range []Event{
+		MoveApplied{}, TilesToCenter{}, FirstPlayerTaken{}, FloorOverflow{}, TilePlacedOnWall{},
+		FloorPenalty{}, RoundEnded{}, GameEnded{}, FactoriesFilled{}, BagRefilled{}, MoveUndone{},
+	} {
+		eventTypes[EventName(e)] = reflect.TypeOf(e)
+	}
+}
+
+// EventName returns the name of an event's type, e.g. "RoundEnded"
+func EventName(e Event) string {
+	return reflect.TypeOf(e).Name()
+}
+
+// DecodeEvent rebuilds an event from its name and JSON encoding
+func DecodeEvent(name string, data []byte) (Event, error) {
+	t, ok := eventTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, fmt.Errorf("event %s: %w", name, err)
+	}
+	return v.Elem().Interface().(Event), nil
+}
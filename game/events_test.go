@@ -0,0 +1,171 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// playRecorded plays a random game and returns it with every event it sent
+// and the number of moves it took. With undo, moves are now and then taken
+// back, and some of them replayed, before play goes on.
+func playRecorded(t *testing.T, seed int64, variant Variant, undo bool) (*Game, []Event, int) {
+	t.Helper()
+	r := &recorder{}
+	g := NewGameWithSeed(3, seed, WithVariant(variant), WithSubscriber(r))
+	rng := rand.New(rand.NewSource(seed))
+
+	for !g.GameOver {
+		valid := g.GetValidMoves()
+		if err := g.ApplyMove(valid[rng.Intn(len(valid))]); err != nil {
+			t.Fatal(err)
+		}
+		if !undo || g.GameOver || rng.Intn(4) > 0 {
+			continue
+		}
+		back := rng.Intn(3) + 1
+		for i := 0; i < back; i++ {
+			g.Undo()
+		}
+		for i := rng.Intn(back + 1); i > 0; i-- {
+			g.Redo()
+		}
+	}
+	return g, r.events, len(g.History())
+}
+
+func TestEventsAddUpToScores(t *testing.T) {
+	for _, variant := range []Variant{ColoredWall, GrayWall} {
+		for seed := int64(1); seed <= 40; seed++ {
+			undo := seed > 20
+			g, events, moves := playRecorded(t, seed, variant, undo)
+
+			scores := make([]int, len(g.Players))
+			applied, ended := 0, 0
+			rounds, markers := map[int]bool{}, map[int]bool{} // Rounds ended and rounds the marker was taken in
+			round := 1
+			for _, e := range events {
+				switch e := e.(type) {
+				case MoveApplied:
+					applied++
+				case MoveUndone:
+					applied--
+					scores = slices.Clone(e.Scores)
+					round = e.Round
+					for r := range rounds {
+						if r >= round {
+							delete(rounds, r)
+						}
+					}
+					for r := range markers {
+						if r > round {
+							delete(markers, r)
+						}
+					}
+				case FactoriesFilled:
+					round = e.Round
+				case FirstPlayerTaken:
+					markers[round] = true
+				case TilePlacedOnWall:
+					scores[e.Player] += e.Points
+					if want := max(e.RowPoints+e.ColumnPoints, 1); e.Points != want {
+						t.Errorf("%s seed %d: tile %+v scores %d, its links give %d", variant, seed, e, e.Points, want)
+					}
+				case FloorPenalty:
+					scores[e.Player] += e.Points
+				case RoundEnded:
+					rounds[e.Round] = true
+					if !reflect.DeepEqual(e.Scores, scores) {
+						t.Errorf("%s seed %d: round %d ends with %v, the events add up to %v", variant, seed, e.Round, e.Scores, scores)
+					}
+				case GameEnded:
+					ended++
+					for i, b := range e.Bonuses {
+						scores[i] += b.Total()
+					}
+					if e.Winner != g.GetWinner() {
+						t.Errorf("%s seed %d: winner %d, want %d", variant, seed, e.Winner, g.GetWinner())
+					}
+				}
+			}
+
+			if applied != moves {
+				t.Errorf("%s seed %d: %d MoveApplied for %d moves", variant, seed, applied, moves)
+			}
+			if len(rounds) != g.Round || len(markers) != g.Round {
+				t.Errorf("%s seed %d: %d rounds ended and %d markers taken in %d rounds", variant, seed, len(rounds), len(markers), g.Round)
+			}
+			if _, last := events[len(events)-1].(GameEnded); ended != 1 || !last {
+				t.Errorf("%s seed %d: GameEnded sent %d times, last event %T", variant, seed, ended, events[len(events)-1])
+			}
+			if !reflect.DeepEqual(scores, g.scores()) {
+				t.Errorf("%s seed %d: final scores %v, the events add up to %v", variant, seed, g.scores(), scores)
+			}
+		}
+	}
+}
+
+func TestMoveEventOrder(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+	r := &recorder{}
+	g.Subscribe(r)
+
+	f := mixedFactory(t, g)
+	color := g.Factories[f].Tiles[0]
+	move := Move{FactoryIdx: f, Color: color, LineIdx: 0}
+	if err := g.ApplyMove(move); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.events) != 2 {
+		t.Fatalf("events %v, want a move and tiles to the center", r.events)
+	}
+	if e, ok := r.events[0].(MoveApplied); !ok || e.Move != move || e.Player != 0 {
+		t.Errorf("first event %+v, want player 1's move %s", r.events[0], move)
+	}
+	if e, ok := r.events[1].(TilesToCenter); !ok || e.Factory != f || len(e.Tiles) != len(g.Center.Tiles) {
+		t.Errorf("second event %+v, want factory %d's leftovers", r.events[1], f+1)
+	}
+
+	r.events = nil
+	if err := g.ApplyMove(Move{FactoryIdx: -1, Color: g.Center.Tiles[0], LineIdx: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.events) != 2 || r.events[1] != (FirstPlayerTaken{Player: 1}) {
+		t.Errorf("events %v, want a move and player 2 taking the marker", r.events)
+	}
+}
+
+func TestEventJSONRoundTrip(t *testing.T) {
+	seen := map[string]bool{}
+	for _, variant := range []Variant{ColoredWall, GrayWall} {
+		_, events, _ := playRecorded(t, 3, variant, true)
+		for _, e := range events {
+			name := EventName(e)
+			seen[name] = true
+			data, err := json.Marshal(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := DecodeEvent(name, data)
+			if err != nil {
+				t.Fatalf("%s: %v", data, err)
+			}
+			if !reflect.DeepEqual(decoded, e) {
+				t.Fatalf("%s %s decodes to %+v, want %+v", name, data, decoded, e)
+			}
+		}
+	}
+
+	// Bag refills and floor overflow are rare in random games
+	for _, name := range []string{"MoveApplied", "TilesToCenter", "FirstPlayerTaken", "TilePlacedOnWall",
+		"FloorPenalty", "RoundEnded", "GameEnded", "FactoriesFilled", "MoveUndone"} {
+		if !seen[name] {
+			t.Errorf("no %s event", name)
+		}
+	}
+	if _, err := DecodeEvent("Nope", []byte("{}")); err == nil {
+		t.Error("decoded an unknown event")
+	}
+}
@@ -0,0 +1,364 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Rule fixtures are plain text files in testdata/*.txt, so rule cases can be
+// added without writing Go. A file holds any number of cases:
+//
+//	# Comment
+//	case A tile with 2 tiles to its left scores 3
+//	wall
+//	  BY...
+//	  .....
+//	  .....
+//	  .....
+//	  .....
+//	place 1 3 scores 3
+//
+// Board directives describe the current board; "player" starts the next one:
+//
+//	variant gray        Rules variant of the case, before any board (default standard)
+//	score N             Score before the checks
+//	wall                Followed by 5 rows of 5 cells: "." empty, "x" the
+//	                    colored wall's tile, or a color letter (B Y R K W)
+//	line N TILES        Pattern line N (1-5) holds TILES, e.g. "line 3 RR"
+//	floor TILES         Floor line, "1" is the first player marker: "floor 1BB";
+//	                    tiles beyond the 7 slots fall into the lid
+//
+// Checks apply to the current board, except winner:
+//
+//	place ROW COL scores N   ScoreWallTile for a tile at ROW, COL (1-5)
+//	round N                  Score after TileWall and ScoreFloorLine
+//	final N                  Score after the round and ScoreEndGame
+//	lid N                    Tiles in the lid after the round, with floor overflow
+//	breakdown KEY N ...      ProjectRound(true) fields: tiles, placement,
+//	                         adjacency, floor, rows, columns, colors
+//	winner N                 GetWinner of the finished game, 1-based (0 = shared)
+
+// fixtureCase is one named rule case
+type fixtureCase struct {
+	name    string
+	pos     string // file:line of the case
+	variant Variant
+	boards  []*fixtureBoard
+	winner  *int
+}
+
+// fixtureBoard is a board of a case with the checks on it
+type fixtureBoard struct {
+	board  *PlayerBoard
+	lid    int // Tiles that fell past the floor line
+	checks []fixtureCheck
+}
+
+// fixtureCheck is one expected outcome
+type fixtureCheck struct {
+	pos  string
+	kind string
+	args []int
+	keys []string // breakdown only
+}
+
+// loadFixtures parses every case in testdata/*.txt
+func loadFixtures(t *testing.T) []*fixtureCase {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no fixtures in testdata")
+	}
+
+	var cases []*fixtureCase
+	for _, path := range paths {
+		fileCases, err := parseFixtureFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases = append(cases, fileCases...)
+	}
+	return cases
+}
+
+// parseFixtureFile reads the cases of one fixture file
+func parseFixtureFile(path string) ([]*fixtureCase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cases []*fixtureCase
+	var c *fixtureCase
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+
+	next := func() (string, bool) {
+		for scanner.Scan() {
+			lineNo++
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				return line, true
+			}
+		}
+		return "", false
+	}
+
+	for {
+		line, ok := next()
+		if !ok {
+			break
+		}
+		pos := fmt.Sprintf("%s:%d", path, lineNo)
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("%s: %s", pos, fmt.Sprintf(format, args...))
+		}
+
+		keyword, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+		if keyword == "case" {
+			c = &fixtureCase{name: rest, pos: pos, boards: []*fixtureBoard{{board: NewPlayerBoard()}}}
+			cases = append(cases, c)
+			continue
+		}
+		if c == nil {
+			return nil, fail("%q before the first case", keyword)
+		}
+		fb := c.boards[len(c.boards)-1]
+		pb := fb.board
+
+		switch keyword {
+		case "variant":
+			v, ok := VariantFromString(rest)
+			if !ok {
+				return nil, fail("unknown variant %q", rest)
+			}
+			c.variant = v
+			for _, b := range c.boards {
+				b.board.Variant = v
+			}
+		case "player":
+			board := NewPlayerBoard()
+			board.Variant = c.variant
+			c.boards = append(c.boards, &fixtureBoard{board: board})
+		case "score":
+			n, err := strconv.Atoi(rest)
+			if err != nil {
+				return nil, fail("bad score %q", rest)
+			}
+			pb.Score = n
+		case "wall":
+			for row := 0; row < 5; row++ {
+				cells, ok := next()
+				if !ok || len(cells) != 5 {
+					return nil, fail("wall row %d must have 5 cells", row+1)
+				}
+				for col, cell := range cells {
+					if err := setWallCell(pb, row, col, cell); err != nil {
+						return nil, fail("wall row %d: %v", row+1, err)
+					}
+				}
+			}
+		case "line":
+			fields := strings.Fields(rest)
+			if len(fields) != 2 {
+				return nil, fail("line needs a line number and tiles")
+			}
+			n, err := strconv.Atoi(fields[0])
+			if err != nil || n < 1 || n > 5 {
+				return nil, fail("bad line number %q", fields[0])
+			}
+			color, ok := ColorFromString(fields[1][:1])
+			if !ok || strings.Count(fields[1], fields[1][:1]) != len(fields[1]) || len(fields[1]) > n {
+				return nil, fail("line %d: bad tiles %q", n, fields[1])
+			}
+			pb.PatternLines[n-1].Add(color, len(fields[1]))
+		case "floor":
+			for _, r := range rest {
+				if r == '1' {
+					fb.lid += len(pb.AddToFloor(FirstPlayerMarker))
+					continue
+				}
+				color, ok := ColorFromString(string(r))
+				if !ok {
+					return nil, fail("bad floor tile %q", r)
+				}
+				fb.lid += len(pb.AddToFloor(color))
+			}
+		case "place":
+			// place ROW COL scores N
+			fields := strings.Fields(rest)
+			if len(fields) != 4 || fields[2] != "scores" {
+				return nil, fail("use: place ROW COL scores N")
+			}
+			args, err := atoiAll(fields[0], fields[1], fields[3])
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			fb.checks = append(fb.checks, fixtureCheck{pos: pos, kind: keyword, args: args})
+		case "round", "final", "lid":
+			args, err := atoiAll(rest)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			fb.checks = append(fb.checks, fixtureCheck{pos: pos, kind: keyword, args: args})
+		case "breakdown":
+			fields := strings.Fields(rest)
+			if len(fields)%2 != 0 {
+				return nil, fail("breakdown needs KEY N pairs")
+			}
+			check := fixtureCheck{pos: pos, kind: keyword}
+			for i := 0; i < len(fields); i += 2 {
+				n, err := strconv.Atoi(fields[i+1])
+				if err != nil {
+					return nil, fail("bad number %q", fields[i+1])
+				}
+				check.keys = append(check.keys, fields[i])
+				check.args = append(check.args, n)
+			}
+			fb.checks = append(fb.checks, check)
+		case "winner":
+			n, err := strconv.Atoi(rest)
+			if err != nil {
+				return nil, fail("bad winner %q", rest)
+			}
+			c.winner = &n
+		default:
+			return nil, fail("unknown directive %q", keyword)
+		}
+	}
+
+	return cases, scanner.Err()
+}
+
+// setWallCell fills one wall cell from its fixture character
+func setWallCell(pb *PlayerBoard, row, col int, cell rune) error {
+	switch cell {
+	case '.':
+		return nil
+	case 'x':
+		if pb.Variant == GrayWall {
+			return fmt.Errorf("the Gray Wall needs a color letter, not x")
+		}
+		pb.Wall[row][col] = true
+		pb.WallColors[row][col] = WallPattern[row][col]
+		return nil
+	}
+
+	color, ok := ColorFromString(string(cell))
+	if !ok || cell != []rune(color.String())[0] {
+		return fmt.Errorf("bad cell %q (use ., x or B Y R K W)", cell)
+	}
+	if pb.Variant != GrayWall && WallPattern[row][col] != color {
+		return fmt.Errorf("column %d holds %s on the colored wall, not %s", col+1, WallPattern[row][col].FullName(), color.FullName())
+	}
+	pb.Wall[row][col] = true
+	pb.WallColors[row][col] = color
+	return nil
+}
+
+// atoiAll parses every string as an int
+func atoiAll(fields ...string) ([]int, error) {
+	ns := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q", f)
+		}
+		ns[i] = n
+	}
+	return ns, nil
+}
+
+func TestRuleFixtures(t *testing.T) {
+	for _, c := range loadFixtures(t) {
+		t.Run(c.name, func(t *testing.T) {
+			for i, fb := range c.boards {
+				for _, check := range fb.checks {
+					runFixtureCheck(t, i, fb, check)
+				}
+			}
+			if c.winner != nil {
+				checkFixtureWinner(t, c)
+			}
+		})
+	}
+}
+
+// runFixtureCheck verifies one check on a copy of the board
+func runFixtureCheck(t *testing.T, player int, fb *fixtureBoard, check fixtureCheck) {
+	t.Helper()
+	pb := fb.board
+	board := pb.Clone()
+	where := fmt.Sprintf("%s (player %d)", check.pos, player+1)
+
+	switch check.kind {
+	case "place":
+		row, col, want := check.args[0]-1, check.args[1]-1, check.args[2]
+		if got := board.ScoreWallTile(row, col); got != want {
+			t.Errorf("%s: tile at %d,%d scores %d, want %d", where, row+1, col+1, got, want)
+		}
+	case "round", "lid":
+		lid := fb.lid + len(board.TileWall())
+		lid += len(board.ScoreFloorLine())
+		got := board.Score
+		projected := pb.ProjectRound(false).Score
+		if check.kind == "lid" {
+			got = lid
+		} else if projected != got {
+			t.Errorf("%s: ProjectRound(false) gives %d, the round scores %d", where, projected, got)
+		}
+		if got != check.args[0] {
+			t.Errorf("%s: %s is %d, want %d", where, check.kind, got, check.args[0])
+		}
+	case "final":
+		board.TileWall()
+		board.ScoreFloorLine()
+		board.ScoreEndGame()
+		if board.Score != check.args[0] {
+			t.Errorf("%s: final score is %d, want %d", where, board.Score, check.args[0])
+		}
+		if projected := pb.ProjectRound(true).Score; projected != board.Score {
+			t.Errorf("%s: ProjectRound(true) gives %d, the game scores %d", where, projected, board.Score)
+		}
+	case "breakdown":
+		p := pb.ProjectRound(true)
+		fields := map[string]int{
+			"tiles": p.Tiles, "placement": p.Placement, "adjacency": p.Adjacency, "floor": p.Floor,
+			"rows": p.RowBonus, "columns": p.ColumnBonus, "colors": p.ColorBonus,
+		}
+		for i, key := range check.keys {
+			got, ok := fields[key]
+			if !ok {
+				t.Errorf("%s: unknown breakdown field %q", where, key)
+				continue
+			}
+			if got != check.args[i] {
+				t.Errorf("%s: breakdown %s is %d, want %d", where, key, got, check.args[i])
+			}
+		}
+	}
+}
+
+// checkFixtureWinner ends a game holding the case's boards and checks the winner
+func checkFixtureWinner(t *testing.T, c *fixtureCase) {
+	t.Helper()
+	g := NewGameWithSeed(len(c.boards), 1, WithVariant(c.variant))
+	for i, fb := range c.boards {
+		g.Players[i] = fb.board.Clone()
+	}
+	g.GameOver = true
+
+	if got := g.GetWinner() + 1; got != *c.winner {
+		t.Errorf("%s: winner is %d, want %d", c.pos, got, *c.winner)
+	}
+}
@@ -1,25 +1,56 @@
 package game
 
 import (
+	"cmp"
 	"fmt"
+	"math/rand"
+	"slices"
 	"time"
 )
 
+// Phase identifies which kind of decision the current player is making
+type Phase int
+
+const (
+	PhaseDrafting   Phase = iota // Taking tiles from factories or the center
+	PhaseWallTiling              // Gray Wall: choosing wall columns for full pattern lines
+)
+
 // Game represents the full game state
 type Game struct {
 	Players       []*PlayerBoard
 	Factories     []*Factory
 	Center        *Center
 	Bag           *Bag
+	Lid           *Lid // Discarded tiles, poured back into the bag when it runs out
 	CurrentPlayer int
 	FirstPlayer   int // Who goes first next round
 	Round         int
 	GameOver      bool
 	NumPlayers    int
+	Variant       Variant
+	Phase         Phase
+
+	history   []HistoryEntry // Applied moves, oldest first
+	redo      []HistoryEntry // Undone moves, most recent last
+	noUndo    bool           // Undo/redo disabled (rated games)
+	noHistory bool           // Don't record moves (AI search clones)
+
+	subscribers []Subscriber // Receive the game's events (see Subscribe)
+}
+
+// Option customizes a game before the first round is set up
+type Option func(*Game)
+
+// WithVariant selects the rules variant (colored or Gray Wall)
+func WithVariant(v Variant) Option {
+	return func(g *Game) {
+		g.Variant = v
+	}
 }
 
 // newGameWithBag is a shared initializer for creating games with a specific bag
-func newGameWithBag(numPlayers int, bag *Bag) *Game {
+func newGameWithBag(numPlayers int, bag *Bag, opts []Option) *Game {
 	if numPlayers < 2 {
 		numPlayers = 2
 	}
@@ -35,6 +66,7 @@ func newGameWithBag(numPlayers int, bag *Bag) *Game {
 		Factories:     make([]*Factory, numFactories),
 		Center:        NewCenter(),
 		Bag:           bag,
+		Lid:           NewLid(),
 		CurrentPlayer: 0,
 		FirstPlayer:   0,
 		Round:         1,
@@ -42,8 +74,13 @@ func newGameWithBag(numPlayers int, bag *Bag) *Game {
 		NumPlayers:    numPlayers,
 	}
 
+	for _, opt := range opts {
+		opt(g)
+	}
+
 	for i := 0; i < numPlayers; i++ {
 		g.Players[i] = NewPlayerBoard()
+		g.Players[i].Variant = g.Variant
 	}
 
 	for i := 0; i < numFactories; i++ {
@@ -55,26 +92,53 @@ func newGameWithBag(numPlayers int, bag *Bag) *Game {
 }
 
 // NewGame creates a new game with the specified number of players
-func NewGame(numPlayers int) *Game {
-	return newGameWithBag(numPlayers, NewBag(time.Now().UnixNano()))
+func NewGame(numPlayers int, opts ...Option) *Game {
+	return newGameWithBag(numPlayers, NewBag(time.Now().UnixNano()), opts)
 }
 
 // NewGameWithSeed creates a game with a specific random seed (for reproducibility)
-func NewGameWithSeed(numPlayers int, seed int64) *Game {
-	return newGameWithBag(numPlayers, NewBag(seed))
+func NewGameWithSeed(numPlayers int, seed int64, opts ...Option) *Game {
+	return newGameWithBag(numPlayers, NewBag(seed), opts)
 }
 
 // SetupRound prepares factories for a new round
+// If the bag and the lid both run out, the round starts with the factories
+// filled as far as the tiles go, as in the rulebook.
 func (g *Game) SetupRound() {
 	// Reset center
 	g.Center = NewCenter()
 
 	// Fill each factory with 4 tiles
-	for _, f := range g.Factories {
+	filled := FactoriesFilled{Round: g.Round, Factories: make([][]TileColor, len(g.Factories))}
+	for i, f := range g.Factories {
 		f.Tiles = f.Tiles[:0]
-		tiles := g.Bag.Draw(4)
+		tiles := g.drawTiles(4)
 		f.Fill(tiles)
+		filled.Factories[i] = tiles
+		filled.Missing += 4 - len(tiles)
 	}
+	g.emit(filled)
+}
+
+// drawTiles draws up to n tiles, refilling the bag from the lid when it runs out
+func (g *Game) drawTiles(n int) []TileColor {
+	tiles := g.Bag.Draw(n)
+	if len(tiles) < n && g.Lid.Len() > 0 {
+		poured := g.Lid.Empty()
+		g.Bag.Refill(poured)
+		g.emit(BagRefilled{Tiles: len(poured)})
+		tiles = append(tiles, g.Bag.Draw(n-len(tiles))...)
+	}
+	return tiles
+}
+
+// toLid puts the tiles that fell past a player's full floor line in the lid
+func (g *Game) toLid(player int, tiles []TileColor) {
+	if len(tiles) == 0 {
+		return
+	}
+	g.Lid.Add(tiles)
+	g.emit(FloorOverflow{Player: player, Tiles: tiles})
 }
 
 // IsRoundOver returns true if all factories and center are empty
@@ -87,14 +151,29 @@ func (g *Game) IsRoundOver() bool {
 	return g.Center.IsEmpty()
 }
 
+// WallTiling is the FactoryIdx of a Gray Wall tiling move
+const WallTiling = -2
+
 // Move represents a player action
+// During PhaseWallTiling a move instead places the tile of the full pattern
+// line LineIdx in wall column Column (FactoryIdx is WallTiling)
 type Move struct {
 	FactoryIdx int       // -1 for center
 	Color      TileColor // Which color to take
 	LineIdx    int       // Which pattern line to place on (-1 for floor)
+	Column     int       // Wall column for Gray Wall tiling moves
+}
+
+// IsWallTiling returns true if the move places a tile on a Gray Wall
+func (m Move) IsWallTiling() bool {
+	return m.FactoryIdx == WallTiling
 }
 
 func (m Move) String() string {
+	if m.IsWallTiling() {
+		return fmt.Sprintf("Place %s from line %d on wall column %d", m.Color.FullName(), m.LineIdx+1, m.Column+1)
+	}
+
 	source := "center"
 	if m.FactoryIdx >= 0 {
 		source = fmt.Sprintf("factory %d", m.FactoryIdx+1)
@@ -110,6 +189,10 @@ func (m Move) String() string {
 
 // GetValidMoves returns all legal moves for the current player
 func (g *Game) GetValidMoves() []Move {
+	if g.Phase == PhaseWallTiling {
+		return g.wallTilingMoves()
+	}
+
 	moves := make([]Move, 0)
 	player := g.Players[g.CurrentPlayer]
 
@@ -141,13 +224,26 @@ func (g *Game) GetValidMoves() []Move {
 }
 
 // ApplyMove executes a move and updates game state
+// The move is recorded in the history (see Undo)
 func (g *Game) ApplyMove(move Move) error {
+	if g.noHistory {
+		return g.applyMove(move)
+	}
+	return g.record(move)
+}
+
+// applyMove validates and executes a move without recording it
+func (g *Game) applyMove(move Move) error {
 	// Validate inputs before any mutation
 	if g.CurrentPlayer < 0 || g.CurrentPlayer >= len(g.Players) {
 		return fmt.Errorf("invalid current player index: %d", g.CurrentPlayer)
 	}
 	player := g.Players[g.CurrentPlayer]
 
+	if g.Phase == PhaseWallTiling {
+		return g.applyWallTilingMove(move)
+	}
+
 	// Validate factory index
 	if move.FactoryIdx != -1 {
 		if move.FactoryIdx < 0 || move.FactoryIdx >= len(g.Factories) {
@@ -177,25 +273,30 @@ func (g *Game) ApplyMove(move Move) error {
 	}
 
 	// All validation passed - now perform mutations
-	var tiles []TileColor
+	var tiles, remaining []TileColor
 	var tookFirstPlayer bool
 
 	if move.FactoryIdx == -1 {
 		// Taking from center
 		tiles, tookFirstPlayer = g.Center.TakeColor(move.Color)
-		if tookFirstPlayer {
-			player.AddToFloor(FirstPlayerMarker)
-			g.FirstPlayer = g.CurrentPlayer
-		}
 	} else {
 		// Taking from factory
-		var remaining []TileColor
 		tiles, remaining = g.Factories[move.FactoryIdx].TakeColor(move.Color)
+	}
+	g.emit(MoveApplied{Player: g.CurrentPlayer, Move: move, Tiles: len(tiles)})
+
+	if len(remaining) > 0 {
 		g.Center.AddTiles(remaining)
+		g.emit(TilesToCenter{Factory: move.FactoryIdx, Tiles: remaining})
+	}
+	if tookFirstPlayer {
+		g.FirstPlayer = g.CurrentPlayer
+		g.emit(FirstPlayerTaken{Player: g.CurrentPlayer})
+		g.toLid(g.CurrentPlayer, player.AddToFloor(FirstPlayerMarker))
 	}
 
 	// Place tiles
-	player.PlaceTiles(move.LineIdx, move.Color, len(tiles))
+	g.toLid(g.CurrentPlayer, player.PlaceTiles(move.LineIdx, move.Color, len(tiles)))
 
 	// Check if round is over
 	if g.IsRoundOver() {
@@ -213,18 +314,46 @@ func (g *Game) NextPlayer() {
 }
 
 // EndRound handles end-of-round scoring and setup
+// On the Gray Wall this enters PhaseWallTiling, and the round finishes once
+// every full pattern line has been placed
 func (g *Game) EndRound() {
-	// Score each player
-	for _, player := range g.Players {
-		// Move tiles to wall
-		discards := player.TileWall()
-		g.Bag.Discard(discards)
+	if g.Variant == GrayWall {
+		g.Phase = PhaseWallTiling
+		g.continueWallTiling()
+		return
+	}
 
-		// Score floor line
-		floorDiscards := player.ScoreFloorLine()
-		g.Bag.Discard(floorDiscards)
+	// Move tiles to wall, top line first as in PlayerBoard.TileWall
+	for p, player := range g.Players {
+		for row, pl := range player.PatternLines {
+			if pl.IsFull() {
+				g.tileLine(p, row, player.GetWallColumn(row, pl.Color))
+			}
+		}
 	}
 
+	g.finishRound()
+}
+
+// finishRound scores floor lines, then ends the game or sets up the next round
+func (g *Game) finishRound() {
+	// Score floor lines
+	for p, player := range g.Players {
+		if len(player.FloorLine) == 0 {
+			continue
+		}
+		penalty := FloorPenalty{
+			Player: p,
+			Tiles:  slices.Clone(player.FloorLine),
+			Slots:  FloorPenalties[:min(len(player.FloorLine), len(FloorPenalties))],
+		}
+		before := player.Score
+		g.Lid.Add(player.ScoreFloorLine())
+		penalty.Points = player.Score - before
+		g.emit(penalty)
+	}
+	g.emit(RoundEnded{Round: g.Round, Scores: g.scores()})
+
 	// Check for game end
 	for _, player := range g.Players {
 		if player.HasCompletedRow() {
@@ -237,92 +366,211 @@ func (g *Game) EndRound() {
 	g.Round++
 	g.CurrentPlayer = g.FirstPlayer
 	g.SetupRound()
+
+	// With every tile on the boards there is nothing left to draft
+	if g.IsRoundOver() {
+		g.EndGame()
+	}
+}
+
+// pendingWallLine returns the next full pattern line waiting to be tiled
+// Players are handled in seat order, lines from top to bottom
+func (g *Game) pendingWallLine() (playerIdx, row int, ok bool) {
+	for p, player := range g.Players {
+		for r, pl := range player.PatternLines {
+			if pl.IsFull() {
+				return p, r, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// continueWallTiling resolves forced Gray Wall placements until a player has
+// a real choice to make, or finishes the round when no full lines remain
+func (g *Game) continueWallTiling() {
+	for {
+		p, row, ok := g.pendingWallLine()
+		if !ok {
+			g.Phase = PhaseDrafting
+			g.finishRound()
+			return
+		}
+
+		player := g.Players[p]
+		options := player.WallColumnOptions(row, player.PatternLines[row].Color)
+		switch len(options) {
+		case 0:
+			// No legal column: the whole line goes to the floor
+			g.toLid(p, player.DumpLineToFloor(row))
+		case 1:
+			g.tileLine(p, row, options[0])
+		default:
+			g.CurrentPlayer = p
+			return
+		}
+	}
+}
+
+// wallTilingMoves returns the column choices for the pending Gray Wall line
+func (g *Game) wallTilingMoves() []Move {
+	moves := make([]Move, 0)
+	p, row, ok := g.pendingWallLine()
+	if !ok || p != g.CurrentPlayer {
+		return moves
+	}
+
+	player := g.Players[p]
+	color := player.PatternLines[row].Color
+	for _, col := range player.WallColumnOptions(row, color) {
+		moves = append(moves, Move{
+			FactoryIdx: WallTiling,
+			Color:      color,
+			LineIdx:    row,
+			Column:     col,
+		})
+	}
+	return moves
+}
+
+// applyWallTilingMove places the pending Gray Wall line in the chosen column
+func (g *Game) applyWallTilingMove(move Move) error {
+	if !move.IsWallTiling() {
+		return fmt.Errorf("wall tiling in progress: choose a wall column for a full line")
+	}
+
+	p, row, ok := g.pendingWallLine()
+	if !ok || p != g.CurrentPlayer {
+		return fmt.Errorf("no pattern line waiting to be tiled for player %d", g.CurrentPlayer+1)
+	}
+	if move.LineIdx != row {
+		return fmt.Errorf("line %d must be tiled next, not line %d", row+1, move.LineIdx+1)
+	}
+
+	player := g.Players[p]
+	valid := false
+	for _, col := range player.WallColumnOptions(row, player.PatternLines[row].Color) {
+		if col == move.Column {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("cannot place %s in wall column %d of row %d", player.PatternLines[row].Color.FullName(), move.Column+1, row+1)
+	}
+
+	g.emit(MoveApplied{Player: p, Move: move})
+	g.tileLine(p, row, move.Column)
+	g.continueWallTiling()
+	return nil
+}
+
+// tileLine moves the tile of a player's full pattern line to a wall column
+// The rest of the line goes to the lid
+func (g *Game) tileLine(p, row, col int) {
+	player := g.Players[p]
+	color := player.PatternLines[row].Color
+	before := player.Score
+	g.Lid.Add(player.TileLine(row, col))
+
+	rowPoints, columnPoints := player.wallLinks(row, col)
+	g.emit(TilePlacedOnWall{
+		Player:       p,
+		Row:          row,
+		Column:       col,
+		Color:        color,
+		Points:       player.Score - before,
+		RowPoints:    rowPoints,
+		ColumnPoints: columnPoints,
+	})
+}
+
+// scores returns every player's score
+func (g *Game) scores() []int {
+	scores := make([]int, len(g.Players))
+	for i, p := range g.Players {
+		scores[i] = p.Score
+	}
+	return scores
 }
 
 // EndGame handles final scoring
 func (g *Game) EndGame() {
 	g.GameOver = true
 
-	for _, player := range g.Players {
+	ended := GameEnded{Bonuses: make([]Bonuses, len(g.Players))}
+	for i, player := range g.Players {
+		ended.Bonuses[i] = player.EndGameBonuses()
 		player.ScoreEndGame()
 	}
+	ended.Scores = g.scores()
+	ended.Winner = g.GetWinner()
+	g.emit(ended)
 }
 
 // GetWinner returns the winning player index (or -1 for tie)
+// The highest score wins; a tie goes to the most completed wall rows, and a
+// tie on both is a shared victory.
 func (g *Game) GetWinner() int {
 	if !g.GameOver {
 		return -1
 	}
 
-	maxScore := -1
-	winner := -1
-	tie := false
-
+	scores := g.scores()
+	rows := make([]int, len(g.Players))
 	for i, player := range g.Players {
-		if player.Score > maxScore {
-			maxScore = player.Score
-			winner = i
-			tie = false
-		} else if player.Score == maxScore {
-			tie = true
-		}
+		rows[i] = player.CompletedRows()
 	}
+	if leaders := Leaders(scores, rows); len(leaders) == 1 {
+		return leaders[0]
+	}
+	return -1
+}
 
-	if tie {
-		// Tiebreaker: most completed horizontal lines
-		maxRows := -1
-		tempWinner := -1
-		tieOnRows := false
-
-		for i, player := range g.Players {
-			if player.Score != maxScore {
-				continue
-			}
-			rows := 0
-			for row := 0; row < 5; row++ {
-				complete := true
-				for col := 0; col < 5; col++ {
-					if !player.Wall[row][col] {
-						complete = false
-						break
-					}
-				}
-				if complete {
-					rows++
-				}
-			}
-			if rows > maxRows {
-				maxRows = rows
-				tempWinner = i
-				tieOnRows = false
-			} else if rows == maxRows {
-				tieOnRows = true
-			}
-		}
+// CompareResults ranks seat a's final result against seat b's, as GetWinner does
+// scores and rows (completed wall rows) are per seat. It returns a positive
+// number if a is ahead, negative if b is, 0 for a tie on both.
+func CompareResults(scores, rows []int, a, b int) int {
+	if c := cmp.Compare(scores[a], scores[b]); c != 0 {
+		return c
+	}
+	return cmp.Compare(rows[a], rows[b])
+}
 
-		// If multiple players share the top maxRows, return -1 for shared victory
-		if tieOnRows {
-			winner = -1
-		} else {
-			winner = tempWinner
+// Leaders returns the seats tied for first place, as GetWinner ranks them
+// A single leader is the winner; several share the victory.
+func Leaders(scores, rows []int) []int {
+	var leaders []int
+	for seat := range scores {
+		switch {
+		case len(leaders) == 0:
+			leaders = []int{seat}
+		case CompareResults(scores, rows, seat, leaders[0]) > 0:
+			leaders = []int{seat}
+		case CompareResults(scores, rows, seat, leaders[0]) == 0:
+			leaders = append(leaders, seat)
 		}
 	}
-
-	return winner
+	return leaders
 }
 
 // Clone creates a deep copy of the game state (for AI)
+// The copy has no history or subscribers and doesn't record the moves applied to it
 func (g *Game) Clone() *Game {
 	newG := &Game{
 		Players:       make([]*PlayerBoard, g.NumPlayers),
 		Factories:     make([]*Factory, len(g.Factories)),
 		Center:        g.Center.Clone(),
 		Bag:           g.Bag.Clone(),
+		Lid:           g.Lid.Clone(),
 		CurrentPlayer: g.CurrentPlayer,
 		FirstPlayer:   g.FirstPlayer,
 		Round:         g.Round,
 		GameOver:      g.GameOver,
 		NumPlayers:    g.NumPlayers,
+		Variant:       g.Variant,
+		Phase:         g.Phase,
+		noHistory:     true,
 	}
 
 	for i, p := range g.Players {
@@ -335,3 +583,14 @@ func (g *Game) Clone() *Game {
 
 	return newG
 }
+
+// Determinize returns a copy of the game with the hidden bag order replaced by a random one
+// Everything the players can see (boards, factories, center, discards and
+// which tiles are left in the bag) is kept. The bag's seed and random state
+// come from rng, so neither the copy nor its saved form can be used to predict
+// future factory fills. AI search works on determinized copies for the same reason.
+func (g *Game) Determinize(rng *rand.Rand) *Game {
+	d := g.Clone()
+	d.Bag.Reshuffle(rng.Int63())
+	return d
+}
@@ -0,0 +1,453 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// playFirstMoves applies the first legal move until stop returns true or the game ends
+func playFirstMoves(t *testing.T, g *Game, stop func() bool) {
+	t.Helper()
+	for !g.GameOver && !stop() {
+		moves := g.GetValidMoves()
+		if len(moves) == 0 {
+			t.Fatalf("round %d: no legal moves", g.Round)
+		}
+		if err := g.ApplyMove(moves[0]); err != nil {
+			t.Fatalf("round %d: %s: %v", g.Round, moves[0], err)
+		}
+	}
+}
+
+// mixedFactory returns a factory holding more than one color
+func mixedFactory(t *testing.T, g *Game) int {
+	t.Helper()
+	for i, f := range g.Factories {
+		if len(f.GetColors()) > 1 {
+			return i
+		}
+	}
+	t.Fatal("every factory holds a single color")
+	return -1
+}
+
+func TestFirstPlayerMarker(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+
+	// Player 1 drafts a factory; the leftovers go to the center with the marker
+	f := mixedFactory(t, g)
+	if err := g.ApplyMove(Move{FactoryIdx: f, Color: g.Factories[f].Tiles[0], LineIdx: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Center.HasFirstPlayerTile {
+		t.Fatal("taking from a factory took the first player marker")
+	}
+
+	// Player 2 is first to take from the center and gets the marker
+	if err := g.ApplyMove(Move{FactoryIdx: -1, Color: g.Center.Tiles[0], LineIdx: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if g.Center.HasFirstPlayerTile {
+		t.Error("the marker stayed in the center")
+	}
+	if g.FirstPlayer != 1 {
+		t.Errorf("FirstPlayer is %d, want 1", g.FirstPlayer)
+	}
+	if !slices.Contains(g.Players[1].FloorLine, FirstPlayerMarker) {
+		t.Error("the marker isn't on player 2's floor line")
+	}
+
+	// Later center takers don't get it
+	f = mixedFactory(t, g)
+	if err := g.ApplyMove(Move{FactoryIdx: f, Color: g.Factories[f].Tiles[0], LineIdx: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ApplyMove(Move{FactoryIdx: -1, Color: g.Center.Tiles[0], LineIdx: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(g.Players[0].FloorLine, FirstPlayerMarker) {
+		t.Error("a second center taker got the marker")
+	}
+
+	// Player 2 starts the next round and the marker is back in the center
+	playFirstMoves(t, g, func() bool { return g.Round == 2 })
+	if g.GameOver {
+		t.Fatal("the game ended in round 1")
+	}
+	if g.CurrentPlayer != 1 {
+		t.Errorf("round 2 starts with player %d, want 2", g.CurrentPlayer+1)
+	}
+	if !g.Center.HasFirstPlayerTile {
+		t.Error("the marker isn't back in the center")
+	}
+	for i, p := range g.Players {
+		if slices.Contains(p.FloorLine, FirstPlayerMarker) {
+			t.Errorf("the marker is still on player %d's floor line", i+1)
+		}
+	}
+}
+
+func TestBagDraw(t *testing.T) {
+	bag := NewBag(1)
+
+	drawn := bag.Draw(100)
+	if len(drawn) != 100 {
+		t.Fatalf("drew %d tiles from a new bag, want 100", len(drawn))
+	}
+	for _, color := range AllColors() {
+		if n := count(drawn, color); n != 20 {
+			t.Errorf("the bag holds %d %s tiles, want 20", n, color.FullName())
+		}
+	}
+	if got := bag.Draw(4); len(got) != 0 {
+		t.Errorf("drew %d tiles from an empty bag", len(got))
+	}
+
+	bag.Refill(drawn[:6])
+	if got := bag.Draw(10); len(got) != 6 {
+		t.Errorf("drew %d tiles from the last 6, want 6", len(got))
+	}
+}
+
+func TestDeterminizeHidesDrawOrder(t *testing.T) {
+	g := NewGameWithSeed(2, 7)
+
+	// predict replays a bag from its seed past the tiles drawn so far and
+	// returns the next round's fill
+	drawn := 100 - g.Bag.TilesRemaining()
+	predict := func(seed int64) []TileColor {
+		bag := NewBag(seed)
+		bag.Draw(drawn)
+		return bag.Draw(4 * len(g.Factories))
+	}
+
+	doc, err := json.Marshal(g.Determinize(rand.New(rand.NewSource(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	public := &Game{}
+	if err := json.Unmarshal(doc, public); err != nil {
+		t.Fatal(err)
+	}
+	if public.Bag.Seed() == g.Bag.Seed() {
+		t.Fatal("the determinized copy kept the real seed")
+	}
+
+	r := &recorder{}
+	g.Subscribe(r)
+	playFirstMoves(t, g, func() bool { return g.Round > 1 })
+	var next []TileColor
+	for _, e := range r.events {
+		if f, ok := e.(FactoriesFilled); ok && f.Round == 2 {
+			next = slices.Concat(f.Factories...)
+		}
+	}
+	if next == nil {
+		t.Fatal("round 2 wasn't set up")
+	}
+
+	if !slices.Equal(predict(g.Bag.Seed()), next) {
+		t.Fatal("the real seed doesn't predict round 2, so the check below proves nothing")
+	}
+	if slices.Equal(predict(public.Bag.Seed()), next) {
+		t.Error("the determinized seed predicts round 2's factories")
+	}
+	if slices.Equal(public.Bag.Clone().Draw(len(next)), next) {
+		t.Error("the determinized bag order matches round 2's factories")
+	}
+}
+
+// recorder collects a game's events
+type recorder struct {
+	events []Event
+}
+
+func (r *recorder) OnEvent(e Event) {
+	r.events = append(r.events, e)
+}
+
+// emptyFactories clears the factories and the center as at the end of a round
+func emptyFactories(g *Game) {
+	for _, f := range g.Factories {
+		f.Tiles = f.Tiles[:0]
+	}
+	g.Center.Tiles = g.Center.Tiles[:0]
+}
+
+func TestBagRefillsFromLid(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+	emptyFactories(g)
+
+	// 2 tiles left in the bag, the rest of the round's tiles in the lid
+	g.Lid.Add(g.Bag.Draw(g.Bag.TilesRemaining() - 2))
+	lid := g.Lid.Len()
+	r := &recorder{}
+	g.Subscribe(r)
+
+	g.SetupRound()
+
+	for i, f := range g.Factories {
+		if len(f.Tiles) != 4 {
+			t.Errorf("factory %d holds %d tiles, want 4", i+1, len(f.Tiles))
+		}
+	}
+	if g.Lid.Len() != 0 || g.Bag.TilesRemaining() != lid+2-20 {
+		t.Errorf("lid %d, bag %d after the refill, want 0 and %d", g.Lid.Len(), g.Bag.TilesRemaining(), lid+2-20)
+	}
+
+	want := []Event{BagRefilled{Tiles: lid}, FactoriesFilled{}}
+	if len(r.events) != len(want) {
+		t.Fatalf("events %v, want a refill and a fill", r.events)
+	}
+	if r.events[0] != want[0] {
+		t.Errorf("event %v, want %v", r.events[0], want[0])
+	}
+	if filled, ok := r.events[1].(FactoriesFilled); !ok || filled.Missing != 0 || len(filled.Factories) != 5 {
+		t.Errorf("event %+v, want 5 full factories", r.events[1])
+	}
+}
+
+func TestPartialRefill(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+	emptyFactories(g)
+
+	// Bag and lid run out after 6 tiles: the factories are filled in order
+	g.Bag.Draw(g.Bag.TilesRemaining() - 6)
+	r := &recorder{}
+	g.Subscribe(r)
+
+	g.SetupRound()
+
+	for i, want := range []int{4, 2, 0, 0, 0} {
+		if got := len(g.Factories[i].Tiles); got != want {
+			t.Errorf("factory %d holds %d tiles, want %d", i+1, got, want)
+		}
+	}
+	if len(r.events) != 1 {
+		t.Fatalf("events %v, want a fill", r.events)
+	}
+	if filled := r.events[0].(FactoriesFilled); filled.Missing != 14 {
+		t.Errorf("%d tiles missing, want 14", filled.Missing)
+	}
+}
+
+func TestFloorOverflowGoesToLid(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+	r := &recorder{}
+	g.Subscribe(r)
+
+	// A full floor line sends every tile of the draft to the lid
+	p := g.Players[0]
+	for i := 0; i < FloorSlots; i++ {
+		p.AddToFloor(Red)
+	}
+	f := mixedFactory(t, g)
+	color := g.Factories[f].Tiles[0]
+	n := count(g.Factories[f].Tiles, color)
+	if err := g.ApplyMove(Move{FactoryIdx: f, Color: color, LineIdx: -1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(p.FloorLine) != FloorSlots {
+		t.Errorf("floor line holds %d tiles, want %d", len(p.FloorLine), FloorSlots)
+	}
+	if g.Lid.Len() != n {
+		t.Errorf("lid holds %d tiles, want %d", g.Lid.Len(), n)
+	}
+	want := FloorOverflow{Player: 0, Tiles: slices.Repeat([]TileColor{color}, n)}
+	if !slices.ContainsFunc(r.events, func(e Event) bool { return reflect.DeepEqual(e, want) }) {
+		t.Errorf("events %v, want %v", r.events, want)
+	}
+}
+
+func TestMarkerOnFullFloor(t *testing.T) {
+	pb := NewPlayerBoard()
+	for i := 0; i < FloorSlots; i++ {
+		pb.AddToFloor(Blue)
+	}
+	if over := pb.AddToFloor(Red); len(over) != 1 || over[0] != Red {
+		t.Errorf("a tile on a full floor line returned %v, want it back", over)
+	}
+
+	// The marker takes the last slot and its tile goes to the lid
+	if over := pb.AddToFloor(FirstPlayerMarker); len(over) != 1 || over[0] != Blue {
+		t.Errorf("the marker on a full floor line returned %v, want the last tile", over)
+	}
+	if len(pb.FloorLine) != FloorSlots || pb.FloorLine[FloorSlots-1] != FirstPlayerMarker {
+		t.Errorf("floor line %v, want the marker in the last slot", pb.FloorLine)
+	}
+}
+
+func TestSubscribersSurviveUndo(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+	r := &recorder{}
+	g.Subscribe(r)
+
+	if len(g.Clone().subscribers) != 0 {
+		t.Error("a clone has subscribers")
+	}
+	if err := g.ApplyMove(g.GetValidMoves()[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := g.Undo(); !ok {
+		t.Fatal("can't undo")
+	}
+	if len(g.subscribers) != 1 {
+		t.Errorf("%d subscribers after an undo, want 1", len(g.subscribers))
+	}
+}
+
+// count returns how many tiles of a color are in tiles
+func count(tiles []TileColor, color TileColor) int {
+	n := 0
+	for _, t := range tiles {
+		if t == color {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRoundWithoutCompleteRowContinues(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+	playFirstMoves(t, g, func() bool { return g.Round == 2 })
+
+	if g.GameOver {
+		t.Fatal("the game ended without a complete row")
+	}
+	for i, f := range g.Factories {
+		if len(f.Tiles) != 4 {
+			t.Errorf("factory %d holds %d tiles after the refill, want 4", i+1, len(f.Tiles))
+		}
+	}
+}
+
+func TestCompleteRowEndsGame(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+	for _, f := range g.Factories {
+		f.Tiles = f.Tiles[:0]
+	}
+
+	// Player 1 completes the top row with the round's last tile
+	p := g.Players[0]
+	for col := 0; col < 4; col++ {
+		p.Wall[0][col] = true
+		p.WallColors[0][col] = WallPattern[0][col]
+	}
+	p.PatternLines[0].Add(WallPattern[0][4], 1)
+	g.Players[1].Score = 10
+
+	g.EndRound()
+
+	if !g.GameOver {
+		t.Fatal("completing a row didn't end the game")
+	}
+	if g.Round != 1 {
+		t.Errorf("round is %d after the game ended, want 1", g.Round)
+	}
+	// 5 for the tile, 2 for the complete row
+	if p.Score != 7 {
+		t.Errorf("player 1 scores %d, want 7", p.Score)
+	}
+	if g.GetWinner() != 1 {
+		t.Errorf("winner is player %d, want 2", g.GetWinner()+1)
+	}
+}
+
+func TestLeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		scores  []int
+		rows    []int
+		leaders []int
+	}{
+		{"highest score", []int{40, 30, 20}, []int{1, 1, 0}, []int{0}},
+		{"equal score, unequal rows", []int{40, 40, 20}, []int{1, 2, 0}, []int{1}},
+		{"shared victory, a third seat lost on rows", []int{40, 40, 40}, []int{2, 2, 1}, []int{0, 1}},
+		{"three-way shared victory", []int{40, 40, 40}, []int{2, 2, 2}, []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Leaders(tt.scores, tt.rows); !slices.Equal(got, tt.leaders) {
+				t.Errorf("Leaders = %v, want %v", got, tt.leaders)
+			}
+			for seat := range tt.scores {
+				c := CompareResults(tt.scores, tt.rows, seat, tt.leaders[0])
+				if lead := slices.Contains(tt.leaders, seat); lead && c != 0 || !lead && c >= 0 {
+					t.Errorf("seat %d compares %d with the leader", seat+1, c)
+				}
+			}
+		})
+	}
+}
+
+func TestGamePlaysToCompletion(t *testing.T) {
+	for _, variant := range []Variant{ColoredWall, GrayWall} {
+		g := NewGameWithSeed(3, 7, WithVariant(variant))
+		playFirstMoves(t, g, func() bool { return g.Round > 50 })
+		if !g.GameOver {
+			t.Fatalf("%s: the game is still running after 50 rounds", variant)
+		}
+
+		complete := false
+		for _, p := range g.Players {
+			complete = complete || p.HasCompletedRow()
+		}
+		if !complete {
+			t.Errorf("%s: the game ended without a complete row", variant)
+		}
+	}
+}
+
+func TestProjectRoundKeepsBoard(t *testing.T) {
+	for _, variant := range []Variant{ColoredWall, GrayWall} {
+		pb := NewPlayerBoard()
+		pb.Variant = variant
+		pb.Score = 12
+		pb.Wall[0][0] = true
+		pb.WallColors[0][0] = WallPattern[0][0]
+		pb.PatternLines[0].Add(Yellow, 1)
+		pb.PatternLines[1].Add(Red, 2)
+		pb.PatternLines[2].Add(Black, 1)
+		pb.AddToFloor(FirstPlayerMarker)
+		pb.AddToFloor(White)
+
+		before := pb.Clone()
+		p := pb.ProjectRound(true)
+		if !reflect.DeepEqual(pb, before) {
+			t.Errorf("%s: ProjectRound changed the board", variant)
+		}
+		if p.Current != 12 || p.Tiles != 2 {
+			t.Errorf("%s: projection from %d with %d tiles, want 12 with 2", variant, p.Current, p.Tiles)
+		}
+	}
+}
+
+func TestLoadVersion1Save(t *testing.T) {
+	// Version 1 kept discarded tiles in the bag and any number of floor tiles
+	v1 := `{"version":1,"variant":"colored","numPlayers":2,"round":3,"phase":"drafting",
+		"currentPlayer":0,"firstPlayer":0,"gameOver":false,
+		"players":[
+			{"score":4,"lines":["","","","",""],"wall":[".....",".....",".....",".....","....."],"floor":"RRRRRRRRR"},
+			{"score":0,"lines":["","","","",""],"wall":[".....",".....",".....",".....","....."],"floor":""}],
+		"factories":["BYRK","","","",""],"center":{"tiles":"","firstPlayerTile":true},
+		"bag":{"seed":"1","rng":"1","tiles":"KKKW","discards":"BBY"}}`
+
+	g := &Game{}
+	if err := g.UnmarshalJSON([]byte(v1)); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Players[0].FloorLine) != FloorSlots {
+		t.Errorf("floor line holds %d tiles, want %d", len(g.Players[0].FloorLine), FloorSlots)
+	}
+	if want := []TileColor{Blue, Blue, Yellow, Red, Red}; !slices.Equal(g.Lid.Tiles, want) {
+		t.Errorf("lid %v, want the discards and the floor overflow %v", g.Lid.Tiles, want)
+	}
+	if g.Bag.TilesRemaining() != 4 {
+		t.Errorf("bag holds %d tiles, want 4", g.Bag.TilesRemaining())
+	}
+}
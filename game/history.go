@@ -0,0 +1,123 @@
+package game
+
+// HistoryEntry records one applied move
+type HistoryEntry struct {
+	Player     int  // Who made the move
+	Move       Move // The move as applied
+	Round      int  // Round in which the move was made
+	RoundEnded bool // The move finished the round (wall tiling, next round setup or game end)
+	before     *Game
+}
+
+// WithUndo controls whether Undo and Redo are allowed (disable for rated games)
+// Moves are still recorded in the history either way
+func WithUndo(allowed bool) Option {
+	return func(g *Game) {
+		g.SetUndoAllowed(allowed)
+	}
+}
+
+// SetUndoAllowed enables or disables Undo and Redo
+// Disabling drops the redo list and the snapshots kept for undoing
+func (g *Game) SetUndoAllowed(allowed bool) {
+	g.noUndo = !allowed
+	if !allowed {
+		g.redo = nil
+		for i := range g.history {
+			g.history[i].before = nil
+		}
+	}
+}
+
+// History returns the moves applied so far, oldest first
+func (g *Game) History() []HistoryEntry {
+	history := make([]HistoryEntry, len(g.history))
+	copy(history, g.history)
+	return history
+}
+
+// CanUndo returns true if there is a move to take back
+func (g *Game) CanUndo() bool {
+	return !g.noUndo && len(g.history) > 0
+}
+
+// CanRedo returns true if there is an undone move to replay
+func (g *Game) CanRedo() bool {
+	return !g.noUndo && len(g.redo) > 0
+}
+
+// Undo takes back the last move, restoring the state before it
+// Subscribers are sent MoveUndone.
+func (g *Game) Undo() (HistoryEntry, bool) {
+	if !g.CanUndo() {
+		return HistoryEntry{}, false
+	}
+
+	entry := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.restore(entry.before)
+	g.redo = append(g.redo, entry)
+	g.emit(MoveUndone{Player: entry.Player, Move: entry.Move, Round: g.Round, Scores: g.scores()})
+
+	return entry, true
+}
+
+// Redo replays the most recently undone move
+// The bag's random state is restored by Undo, so refills repeat exactly, and
+// the move's events are sent again.
+func (g *Game) Redo() (HistoryEntry, bool) {
+	if !g.CanRedo() {
+		return HistoryEntry{}, false
+	}
+
+	entry := g.redo[len(g.redo)-1]
+	g.redo = g.redo[:len(g.redo)-1]
+	if err := g.applyMove(entry.Move); err != nil {
+		g.redo = nil
+		return HistoryEntry{}, false
+	}
+	g.history = append(g.history, entry)
+
+	return entry, true
+}
+
+// NextRedo returns the move Redo would replay
+func (g *Game) NextRedo() (HistoryEntry, bool) {
+	if !g.CanRedo() {
+		return HistoryEntry{}, false
+	}
+	return g.redo[len(g.redo)-1], true
+}
+
+// record applies a move and adds it to the history
+func (g *Game) record(move Move) error {
+	entry := HistoryEntry{
+		Player: g.CurrentPlayer,
+		Move:   move,
+		Round:  g.Round,
+	}
+	if !g.noUndo {
+		entry.before = g.Clone()
+	}
+
+	if err := g.applyMove(move); err != nil {
+		return err
+	}
+
+	entry.RoundEnded = g.Round != entry.Round || g.GameOver
+	g.history = append(g.history, entry)
+	g.redo = nil
+
+	return nil
+}
+
+// restore replaces the game state with a copy of a snapshot, keeping the history and subscribers
+func (g *Game) restore(snapshot *Game) {
+	state := snapshot.Clone()
+	state.history = g.history
+	state.redo = g.redo
+	state.noUndo = g.noUndo
+	state.noHistory = g.noHistory
+	state.subscribers = g.subscribers
+	*g = *state
+}
@@ -0,0 +1,36 @@
+package game
+
+// Lid is the box lid: tiles discarded from the boards wait here until the bag
+// runs out and they are poured back into it
+type Lid struct {
+	Tiles []TileColor
+}
+
+// NewLid creates an empty lid
+func NewLid() *Lid {
+	return &Lid{Tiles: make([]TileColor, 0, 100)}
+}
+
+// Add puts tiles in the lid
+func (l *Lid) Add(tiles []TileColor) {
+	l.Tiles = append(l.Tiles, tiles...)
+}
+
+// Len returns the number of tiles in the lid
+func (l *Lid) Len() int {
+	return len(l.Tiles)
+}
+
+// Empty removes and returns every tile in the lid
+func (l *Lid) Empty() []TileColor {
+	tiles := l.Tiles
+	l.Tiles = make([]TileColor, 0, 100)
+	return tiles
+}
+
+// Clone creates a deep copy
+func (l *Lid) Clone() *Lid {
+	newL := &Lid{Tiles: make([]TileColor, len(l.Tiles))}
+	copy(newL.Tiles, l.Tiles)
+	return newL
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Move notation
+//
+// A drafting move is written as source, color and destination:
+//
+//	3B2   take Blue from factory 3, place on pattern line 2
+//	CY-   take Yellow from the center, place on the floor
+//
+// Sources are factory numbers (1-9) or C for the center. Colors use the tile
+// letters B, Y, R, K (black) and W. The destination is a pattern line (1-5) or
+// - for the floor.
+//
+// A Gray Wall tiling move is written as line, color, @ and wall column:
+//
+//	2B@3  place the Blue tile of full line 2 in wall column 3
+//
+// Parsing is case-insensitive.
+
+// FormatMove writes a move in standard notation
+func FormatMove(m Move) string {
+	if m.IsWallTiling() {
+		return fmt.Sprintf("%d%s@%d", m.LineIdx+1, m.Color, m.Column+1)
+	}
+
+	source := "C"
+	if m.FactoryIdx >= 0 {
+		source = fmt.Sprintf("%d", m.FactoryIdx+1)
+	}
+
+	dest := "-"
+	if m.LineIdx >= 0 {
+		dest = fmt.Sprintf("%d", m.LineIdx+1)
+	}
+
+	return source + m.Color.String() + dest
+}
+
+// ParseMove reads a move written in standard notation
+// The move is only checked for syntax; use Game.ApplyMove to check legality
+func ParseMove(s string) (Move, error) {
+	text := strings.ToUpper(strings.TrimSpace(s))
+
+	if at := strings.IndexByte(text, '@'); at >= 0 {
+		if at != 2 || len(text) != 4 {
+			return Move{}, fmt.Errorf("invalid wall move %q (expected e.g. 2B@3)", s)
+		}
+		line, ok := digit(text[0], 5)
+		if !ok {
+			return Move{}, fmt.Errorf("invalid pattern line in %q", s)
+		}
+		color, ok := ColorFromString(text[1:2])
+		if !ok {
+			return Move{}, fmt.Errorf("invalid color in %q", s)
+		}
+		col, ok := digit(text[3], 5)
+		if !ok {
+			return Move{}, fmt.Errorf("invalid wall column in %q", s)
+		}
+		return Move{FactoryIdx: WallTiling, Color: color, LineIdx: line, Column: col}, nil
+	}
+
+	if len(text) != 3 {
+		return Move{}, fmt.Errorf("invalid move %q (expected e.g. 3B2 or CY-)", s)
+	}
+
+	move := Move{FactoryIdx: -1, LineIdx: -1}
+
+	if text[0] != 'C' {
+		factory, ok := digit(text[0], 9)
+		if !ok {
+			return Move{}, fmt.Errorf("invalid source in %q (use 1-9 or C)", s)
+		}
+		move.FactoryIdx = factory
+	}
+
+	color, ok := ColorFromString(text[1:2])
+	if !ok {
+		return Move{}, fmt.Errorf("invalid color in %q (use B, Y, R, K or W)", s)
+	}
+	move.Color = color
+
+	if text[2] != '-' {
+		line, ok := digit(text[2], 5)
+		if !ok {
+			return Move{}, fmt.Errorf("invalid destination in %q (use 1-5 or -)", s)
+		}
+		move.LineIdx = line
+	}
+
+	return move, nil
+}
+
+// MarshalText writes the move in standard notation
+func (m Move) MarshalText() ([]byte, error) {
+	return []byte(FormatMove(m)), nil
+}
+
+// UnmarshalText reads a move written in standard notation
+func (m *Move) UnmarshalText(text []byte) error {
+	move, err := ParseMove(string(text))
+	if err != nil {
+		return err
+	}
+	*m = move
+	return nil
+}
+
+// digit parses a 1-based digit up to limit and returns it 0-based
+func digit(c byte, limit int) (int, bool) {
+	n := int(c - '0')
+	if c < '1' || n > limit {
+		return 0, false
+	}
+	return n - 1, true
+}
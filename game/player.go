@@ -73,17 +73,22 @@ func (p *PatternLine) Clone() *PatternLine {
 type PlayerBoard struct {
 	PatternLines [5]*PatternLine // 5 pattern lines (rows 1-5)
 	Wall         [5][5]bool      // Which wall positions are filled
+	WallColors   [5][5]TileColor // Color placed at each filled position (see WallColor)
 	FloorLine    []TileColor     // Negative point tiles
 	Score        int
+	Variant      Variant // Which side of the board is in use
 }
 
+// FloorSlots is the number of spaces on the floor line
+const FloorSlots = 7
+
 // Floor line penalties
 var FloorPenalties = []int{-1, -1, -2, -2, -2, -3, -3}
 
 // NewPlayerBoard creates an empty player board
 func NewPlayerBoard() *PlayerBoard {
 	pb := &PlayerBoard{
-		FloorLine: make([]TileColor, 0, 7),
+		FloorLine: make([]TileColor, 0, FloorSlots),
 		Score:     0,
 	}
 
@@ -111,8 +116,7 @@ func (pb *PlayerBoard) CanPlaceOnLine(lineIdx int, color TileColor) bool {
 	}
 
 	// Check if wall already has this color in this row
-	wallCol := pb.GetWallColumn(lineIdx, color)
-	if pb.Wall[lineIdx][wallCol] {
+	if pb.RowHasColor(lineIdx, color) {
 		return false
 	}
 
@@ -120,43 +124,132 @@ func (pb *PlayerBoard) CanPlaceOnLine(lineIdx int, color TileColor) bool {
 }
 
 // GetWallColumn returns the column index where a color goes in a given row
+// On the Gray Wall a color has no fixed column, so this returns the column it
+// was placed in, or -1 if the row does not hold that color yet
 func (pb *PlayerBoard) GetWallColumn(row int, color TileColor) int {
 	for col := 0; col < 5; col++ {
-		if WallPattern[row][col] == color {
+		if pb.Variant == GrayWall {
+			if pb.Wall[row][col] && pb.WallColors[row][col] == color {
+				return col
+			}
+		} else if WallPattern[row][col] == color {
 			return col
 		}
 	}
-	return -1 // Should never happen
+	return -1
 }
 
-// PlaceTiles adds tiles to a pattern line, overflow goes to floor
-func (pb *PlayerBoard) PlaceTiles(lineIdx int, color TileColor, count int) {
-	if lineIdx == -1 {
-		// Direct to floor line
-		for i := 0; i < count; i++ {
-			pb.FloorLine = append(pb.FloorLine, color)
+// WallColor returns the color shown at a wall position
+// Empty Gray Wall positions have no color and return NoTile
+func (pb *PlayerBoard) WallColor(row, col int) TileColor {
+	if pb.Variant == GrayWall {
+		if !pb.Wall[row][col] {
+			return NoTile
+		}
+		return pb.WallColors[row][col]
+	}
+	return WallPattern[row][col]
+}
+
+// RowHasColor returns true if the wall row already holds a tile of the color
+func (pb *PlayerBoard) RowHasColor(row int, color TileColor) bool {
+	col := pb.GetWallColumn(row, color)
+	return col >= 0 && pb.Wall[row][col]
+}
+
+// columnHasColor returns true if the wall column already holds a tile of the color
+func (pb *PlayerBoard) columnHasColor(col int, color TileColor) bool {
+	for row := 0; row < 5; row++ {
+		if pb.Wall[row][col] && pb.WallColor(row, col) == color {
+			return true
 		}
-		return
 	}
+	return false
+}
 
-	overflow := pb.PatternLines[lineIdx].Add(color, count)
+// WallColumnOptions returns the columns where a tile of the color may be placed in a row
+// The colored wall has at most one option; the Gray Wall allows any empty
+// column that doesn't already hold the color. An empty result means the
+// pattern line cannot be tiled and goes to the floor.
+func (pb *PlayerBoard) WallColumnOptions(row int, color TileColor) []int {
+	options := make([]int, 0)
+	if pb.RowHasColor(row, color) {
+		return options
+	}
 
-	// Overflow goes to floor
+	if pb.Variant != GrayWall {
+		if col := pb.GetWallColumn(row, color); col >= 0 {
+			options = append(options, col)
+		}
+		return options
+	}
+
+	for col := 0; col < 5; col++ {
+		if !pb.Wall[row][col] && !pb.columnHasColor(col, color) {
+			options = append(options, col)
+		}
+	}
+	return options
+}
+
+// ColorCount returns how many tiles of the color are on the wall
+func (pb *PlayerBoard) ColorCount(color TileColor) int {
+	count := 0
+	for row := 0; row < 5; row++ {
+		if pb.RowHasColor(row, color) {
+			count++
+		}
+	}
+	return count
+}
+
+// PlaceTiles adds tiles to a pattern line, overflow goes to floor
+// Returns the tiles that don't fit on the floor line either, which go to the lid
+func (pb *PlayerBoard) PlaceTiles(lineIdx int, color TileColor, count int) []TileColor {
+	overflow := count
+	if lineIdx != -1 {
+		overflow = pb.PatternLines[lineIdx].Add(color, count)
+	}
+
+	var lid []TileColor
 	for i := 0; i < overflow; i++ {
-		pb.FloorLine = append(pb.FloorLine, color)
+		lid = append(lid, pb.AddToFloor(color)...)
 	}
+	return lid
 }
 
 // AddToFloor adds a tile to the floor line
-func (pb *PlayerBoard) AddToFloor(color TileColor) {
-	pb.FloorLine = append(pb.FloorLine, color)
+// Returns the tile if the floor line is full, as it goes to the lid. The first
+// player marker always takes a slot: on a full floor line it replaces the last
+// tile, which goes to the lid instead.
+func (pb *PlayerBoard) AddToFloor(color TileColor) []TileColor {
+	if len(pb.FloorLine) < FloorSlots {
+		pb.FloorLine = append(pb.FloorLine, color)
+		return nil
+	}
+	if color != FirstPlayerMarker {
+		return []TileColor{color}
+	}
+	last := pb.FloorLine[FloorSlots-1]
+	pb.FloorLine[FloorSlots-1] = color
+	return []TileColor{last}
 }
 
 // ScoreWallTile calculates points for placing a tile on the wall
 // Points = 1 + adjacent tiles in row + adjacent tiles in column
 func (pb *PlayerBoard) ScoreWallTile(row, col int) int {
-	points := 0
+	rowPoints, columnPoints := pb.wallLinks(row, col)
+
+	// If only the tile itself (no adjacents), score 1
+	if rowPoints == 0 && columnPoints == 0 {
+		return 1
+	}
+	return rowPoints + columnPoints
+}
 
+// wallLinks returns the length of the row and column lines a tile at row, col
+// joins, itself included, or 0 for a direction with no adjacent tile
+func (pb *PlayerBoard) wallLinks(row, col int) (rowPoints, columnPoints int) {
 	// Count horizontal adjacent
 	hCount := 1
 	for c := col - 1; c >= 0 && pb.Wall[row][c]; c-- {
@@ -175,29 +268,19 @@ func (pb *PlayerBoard) ScoreWallTile(row, col int) int {
 		vCount++
 	}
 
-	// If only the tile itself (no adjacents), score 1
-	// If horizontal line, add hCount
-	// If vertical line, add vCount
-	if hCount == 1 && vCount == 1 {
-		points = 1
-	} else {
-		if hCount > 1 {
-			points += hCount
-		}
-		if vCount > 1 {
-			points += vCount
-		}
-		// If isolated but we got here, something's wrong - default to 1
-		if points == 0 {
-			points = 1
-		}
+	if hCount > 1 {
+		rowPoints = hCount
 	}
-
-	return points
+	if vCount > 1 {
+		columnPoints = vCount
+	}
+	return rowPoints, columnPoints
 }
 
 // TileWall moves completed pattern lines to wall and scores
-// Returns tiles to be discarded
+// Returns tiles to be discarded to the lid
+// On the Gray Wall each line goes to its highest-scoring legal column; games
+// let the player choose instead (see Game.PhaseWallTiling)
 func (pb *PlayerBoard) TileWall() []TileColor {
 	discards := make([]TileColor, 0)
 
@@ -207,24 +290,62 @@ func (pb *PlayerBoard) TileWall() []TileColor {
 			continue
 		}
 
-		color, count := pl.Clear()
-		col := pb.GetWallColumn(row, color)
+		col, ok := pb.bestWallColumn(row)
+		if !ok {
+			discards = append(discards, pb.DumpLineToFloor(row)...)
+			continue
+		}
+		discards = append(discards, pb.TileLine(row, col)...)
+	}
 
-		// Place tile on wall
-		pb.Wall[row][col] = true
+	return discards
+}
 
-		// Score it
-		pb.Score += pb.ScoreWallTile(row, col)
+// bestWallColumn returns the highest-scoring legal column for a full pattern line
+// ok is false if the line has no legal column (Gray Wall)
+func (pb *PlayerBoard) bestWallColumn(row int) (col int, ok bool) {
+	options := pb.WallColumnOptions(row, pb.PatternLines[row].Color)
+	if len(options) == 0 {
+		return 0, false
+	}
 
-		// Remaining tiles (count - 1) go to discard
-		for i := 0; i < count-1; i++ {
-			discards = append(discards, color)
+	col = options[0]
+	for _, c := range options[1:] {
+		if pb.ScoreWallTile(row, c) > pb.ScoreWallTile(row, col) {
+			col = c
 		}
 	}
+	return col, true
+}
 
+// TileLine moves the tile of a full pattern line to the given wall column and scores it
+// Returns tiles to be discarded
+func (pb *PlayerBoard) TileLine(row, col int) []TileColor {
+	color, count := pb.PatternLines[row].Clear()
+
+	// Place tile on wall
+	pb.Wall[row][col] = true
+	pb.WallColors[row][col] = color
+
+	// Score it
+	pb.Score += pb.ScoreWallTile(row, col)
+
+	// Remaining tiles (count - 1) go to discard
+	discards := make([]TileColor, 0, count)
+	for i := 0; i < count-1; i++ {
+		discards = append(discards, color)
+	}
 	return discards
 }
 
+// DumpLineToFloor moves every tile of a pattern line to the floor line
+// Used on the Gray Wall when a full line has no legal wall column. Returns the
+// tiles that don't fit on the floor line, which go to the lid.
+func (pb *PlayerBoard) DumpLineToFloor(row int) []TileColor {
+	color, count := pb.PatternLines[row].Clear()
+	return pb.PlaceTiles(-1, color, count)
+}
+
 // ScoreFloorLine applies floor penalties and clears floor
 // Returns tiles to be discarded
 func (pb *PlayerBoard) ScoreFloorLine() []TileColor {
@@ -251,6 +372,13 @@ func (pb *PlayerBoard) ScoreFloorLine() []TileColor {
 
 // ScoreEndGame adds bonus points at end of game
 func (pb *PlayerBoard) ScoreEndGame() {
+	pb.Score += pb.EndGameBonuses().Total()
+}
+
+// EndGameBonuses returns the bonus points for complete rows, columns and colors
+func (pb *PlayerBoard) EndGameBonuses() Bonuses {
+	var rows, columns, colors int
+
 	// Complete horizontal lines: +2 each
 	for row := 0; row < 5; row++ {
 		complete := true
@@ -261,7 +389,7 @@ func (pb *PlayerBoard) ScoreEndGame() {
 			}
 		}
 		if complete {
-			pb.Score += 2
+			rows += 2
 		}
 	}
 
@@ -275,27 +403,28 @@ func (pb *PlayerBoard) ScoreEndGame() {
 			}
 		}
 		if complete {
-			pb.Score += 7
+			columns += 7
 		}
 	}
 
 	// All 5 of one color: +10 each
 	for _, color := range AllColors() {
-		count := 0
-		for row := 0; row < 5; row++ {
-			col := pb.GetWallColumn(row, color)
-			if pb.Wall[row][col] {
-				count++
-			}
-		}
-		if count == 5 {
-			pb.Score += 10
+		if pb.ColorCount(color) == 5 {
+			colors += 10
 		}
 	}
+
+	return Bonuses{Rows: rows, Columns: columns, Colors: colors}
 }
 
 // HasCompletedRow returns true if any wall row is complete (game end trigger)
 func (pb *PlayerBoard) HasCompletedRow() bool {
+	return pb.CompletedRows() > 0
+}
+
+// CompletedRows returns how many wall rows are complete (the winner tiebreak)
+func (pb *PlayerBoard) CompletedRows() int {
+	rows := 0
 	for row := 0; row < 5; row++ {
 		complete := true
 		for col := 0; col < 5; col++ {
@@ -305,18 +434,20 @@ func (pb *PlayerBoard) HasCompletedRow() bool {
 			}
 		}
 		if complete {
-			return true
+			rows++
 		}
 	}
-	return false
+	return rows
 }
 
 // Clone creates a deep copy
 func (pb *PlayerBoard) Clone() *PlayerBoard {
 	newPB := &PlayerBoard{
-		Wall:      pb.Wall, // Arrays are copied by value
-		FloorLine: make([]TileColor, len(pb.FloorLine)),
-		Score:     pb.Score,
+		Wall:       pb.Wall, // Arrays are copied by value
+		WallColors: pb.WallColors,
+		FloorLine:  make([]TileColor, len(pb.FloorLine)),
+		Score:      pb.Score,
+		Variant:    pb.Variant,
 	}
 
 	for i := 0; i < 5; i++ {
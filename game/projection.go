@@ -0,0 +1,68 @@
+package game
+
+// Projection breaks down the score a board would have if the round ended now
+type Projection struct {
+	Current int // Score before the projected scoring
+
+	Tiles     int // Full pattern lines moved to the wall
+	Placement int // 1 point per tile placed on the wall
+	Adjacency int // Points for the rows and columns the placed tiles join
+	Floor     int // Floor penalty (zero or negative), limited so the score stays at 0 or more
+
+	// End-game bonuses, only projected when asked for
+	RowBonus    int // +2 per complete row
+	ColumnBonus int // +7 per complete column
+	ColorBonus  int // +10 per color placed 5 times
+
+	Score int // Score after the projected scoring
+}
+
+// Gain returns the points the projected scoring adds (negative if it costs points)
+func (p Projection) Gain() int {
+	return p.Score - p.Current
+}
+
+// Bonus returns the total of the end-game bonuses
+func (p Projection) Bonus() int {
+	return p.RowBonus + p.ColumnBonus + p.ColorBonus
+}
+
+// ProjectRound returns what end-of-round scoring would do to the board now
+// It simulates TileWall and ScoreFloorLine, then ScoreEndGame if endGame is
+// set, on a copy: the board itself doesn't change. On the Gray Wall, full
+// lines go to their highest-scoring legal column, as in TileWall.
+func (pb *PlayerBoard) ProjectRound(endGame bool) Projection {
+	board := pb.Clone()
+	p := Projection{Current: pb.Score}
+
+	// Tile full pattern lines
+	for row := 0; row < 5; row++ {
+		if !board.PatternLines[row].IsFull() {
+			continue
+		}
+		col, ok := board.bestWallColumn(row)
+		if !ok {
+			board.DumpLineToFloor(row)
+			continue
+		}
+		before := board.Score
+		board.TileLine(row, col)
+		p.Tiles++
+		p.Placement++
+		p.Adjacency += board.Score - before - 1
+	}
+
+	// Floor penalties
+	before := board.Score
+	board.ScoreFloorLine()
+	p.Floor = board.Score - before
+
+	if endGame {
+		b := board.EndGameBonuses()
+		p.RowBonus, p.ColumnBonus, p.ColorBonus = b.Rows, b.Columns, b.Colors
+		board.Score += p.Bonus()
+	}
+
+	p.Score = board.Score
+	return p
+}
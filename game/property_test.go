@@ -0,0 +1,193 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// totalTiles is the number of colored tiles in a game
+const totalTiles = 100
+
+// checkInvariants returns the first rule invariant the game breaks, or nil
+func checkInvariants(g *Game) error {
+	// Every tile is somewhere: bag, lid, factories, center, lines, walls or floors
+	tiles := g.Bag.TilesRemaining() + g.Lid.Len() + len(g.Center.Tiles)
+	for _, f := range g.Factories {
+		tiles += len(f.Tiles)
+	}
+	markers := 0
+	if g.Center.HasFirstPlayerTile {
+		markers++
+	}
+	for i, p := range g.Players {
+		if p.Score < 0 {
+			return fmt.Errorf("player %d has a negative score %d", i+1, p.Score)
+		}
+		for row, pl := range p.PatternLines {
+			tiles += pl.Filled
+			if pl.Filled > pl.Size {
+				return fmt.Errorf("player %d: line %d holds %d tiles", i+1, row+1, pl.Filled)
+			}
+			for col := 0; col < 5; col++ {
+				if p.Wall[row][col] {
+					tiles++
+				}
+			}
+		}
+		if len(p.FloorLine) > FloorSlots {
+			return fmt.Errorf("player %d has %d floor tiles", i+1, len(p.FloorLine))
+		}
+		for _, t := range p.FloorLine {
+			if t == FirstPlayerMarker {
+				markers++
+			} else {
+				tiles++
+			}
+		}
+	}
+	if tiles != totalTiles {
+		return fmt.Errorf("%d tiles in the game, want %d", tiles, totalTiles)
+	}
+	if g.Phase == PhaseDrafting && !g.GameOver && markers != 1 {
+		return fmt.Errorf("%d first player markers while drafting, want 1", markers)
+	}
+
+	if !g.GameOver && len(g.GetValidMoves()) == 0 {
+		return fmt.Errorf("round %d: no legal moves before the game ended", g.Round)
+	}
+	return nil
+}
+
+// snapshot returns the game's save document, which holds its whole state
+func snapshot(t testing.TB, g *Game) []byte {
+	t.Helper()
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// checkClone verifies that a clone equals the game and doesn't share state with it
+func checkClone(t testing.TB, g *Game) {
+	t.Helper()
+	before := snapshot(t, g)
+	c := g.Clone()
+	if !bytes.Equal(snapshot(t, c), before) {
+		t.Fatalf("round %d: the clone differs from the game", g.Round)
+	}
+	if c.Hash() != g.Hash() {
+		t.Fatalf("round %d: the clone hashes differently", g.Round)
+	}
+
+	if moves := c.GetValidMoves(); len(moves) > 0 {
+		if err := c.ApplyMove(moves[len(moves)-1]); err != nil {
+			t.Fatalf("round %d: clone: %v", g.Round, err)
+		}
+	}
+	c.Bag.Draw(totalTiles)
+	if !bytes.Equal(snapshot(t, g), before) {
+		t.Fatalf("round %d: changing the clone changed the game", g.Round)
+	}
+}
+
+// playRandomGame plays a seeded game with random legal moves, checking the
+// invariants after every move, and returns the number of moves
+func playRandomGame(t *testing.T, seed int64) int {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	variant := ColoredWall
+	if rng.Intn(2) == 1 {
+		variant = GrayWall
+	}
+	g := NewGameWithSeed(2+rng.Intn(3), seed, WithVariant(variant))
+
+	moves := 0
+	for !g.GameOver {
+		if err := checkInvariants(g); err != nil {
+			t.Fatalf("seed %d, move %d: %v", seed, moves, err)
+		}
+		if moves%10 == 0 {
+			checkClone(t, g)
+		}
+		valid := g.GetValidMoves()
+		move := valid[rng.Intn(len(valid))]
+		if err := g.ApplyMove(move); err != nil {
+			t.Fatalf("seed %d, move %d: legal move %s rejected: %v", seed, moves, move, err)
+		}
+		moves++
+		if moves > 2000 {
+			t.Fatalf("seed %d: no end after %d moves", seed, moves)
+		}
+	}
+	if err := checkInvariants(g); err != nil {
+		t.Fatalf("seed %d, game over: %v", seed, err)
+	}
+	return moves
+}
+
+func TestRandomGameInvariants(t *testing.T) {
+	games := 2000
+	if testing.Short() {
+		games = 100
+	}
+	for seed := int64(1); seed <= int64(games); seed++ {
+		playRandomGame(t, seed)
+	}
+}
+
+// fuzzMove decodes two fuzz bytes into a move
+// Most pairs pick one of the legal moves; when the high bit of a is set, the
+// pair is a raw move that may be illegal, to exercise ApplyMove's validation.
+func fuzzMove(g *Game, a, b byte) Move {
+	if a&0x80 == 0 {
+		valid := g.GetValidMoves()
+		return valid[int(b)%len(valid)]
+	}
+	return Move{
+		FactoryIdx: int(a&0x0f) - 2,
+		Color:      TileColor(b % 7),
+		LineIdx:    int(b>>3&0x07) - 1,
+		Column:     int(a>>4&0x07) - 1,
+	}
+}
+
+// FuzzMoveSequences plays move sequences from the fuzzer and checks that
+// legal moves keep the invariants and illegal ones change nothing
+func FuzzMoveSequences(f *testing.F) {
+	f.Add(int64(1), uint8(2), false, []byte{})
+	f.Add(int64(2), uint8(3), true, []byte{0, 1, 0, 2, 0, 3, 0x85, 0x21, 0, 4})
+	f.Add(int64(3), uint8(4), false, bytes.Repeat([]byte{0, 7, 0x93, 0x0a}, 40))
+	for seed := int64(4); seed < 8; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		moves := make([]byte, 400)
+		rng.Read(moves)
+		f.Add(seed, uint8(seed), seed%2 == 0, moves)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, players uint8, grayWall bool, moves []byte) {
+		variant := ColoredWall
+		if grayWall {
+			variant = GrayWall
+		}
+		g := NewGameWithSeed(2+int(players%3), seed, WithVariant(variant))
+
+		for i := 0; i+1 < len(moves) && !g.GameOver; i += 2 {
+			move := fuzzMove(g, moves[i], moves[i+1])
+			before := snapshot(t, g)
+			if err := g.ApplyMove(move); err != nil {
+				if !bytes.Equal(snapshot(t, g), before) {
+					t.Fatalf("move %d: rejected move %+v changed the game: %v", i/2, move, err)
+				}
+				continue
+			}
+			if err := checkInvariants(g); err != nil {
+				t.Fatalf("move %d: after %+v: %v", i/2, move, err)
+			}
+		}
+		checkClone(t, g)
+	})
+}
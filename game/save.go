@@ -0,0 +1,327 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SaveVersion is the version written into saved games
+// Bump it whenever the document layout changes incompatibly
+// Version 2 moved the discarded tiles from the bag to the lid.
+const SaveVersion = 2
+
+// savedGame is the versioned JSON document for a game
+// Tiles are written as color letters (B, Y, R, K, W, and 1 for the first player marker)
+type savedGame struct {
+	Version       int           `json:"version"`
+	Variant       string        `json:"variant"`
+	NumPlayers    int           `json:"numPlayers"`
+	Round         int           `json:"round"`
+	Phase         string        `json:"phase"`
+	CurrentPlayer int           `json:"currentPlayer"`
+	FirstPlayer   int           `json:"firstPlayer"`
+	GameOver      bool          `json:"gameOver"`
+	Players       []savedPlayer `json:"players"`
+	Factories     []string      `json:"factories"`
+	Center        savedCenter   `json:"center"`
+	Bag           savedBag      `json:"bag"`
+	Lid           string        `json:"lid"`
+}
+
+type savedPlayer struct {
+	Score int       `json:"score"`
+	Lines [5]string `json:"lines"` // Tiles on each pattern line, e.g. "YY"
+	Wall  [5]string `json:"wall"`  // One row per string, "." for empty, e.g. "B..K."
+	Floor string    `json:"floor"`
+}
+
+type savedCenter struct {
+	Tiles           string `json:"tiles"`
+	FirstPlayerTile bool   `json:"firstPlayerTile"`
+}
+
+type savedBag struct {
+	Seed     int64  `json:"seed,string"`
+	RNG      uint64 `json:"rng,string"`         // Random source state, so shuffles resume exactly
+	Tiles    string `json:"tiles"`              // Draw order: the last tile is drawn first
+	Discards string `json:"discards,omitempty"` // Version 1 only, the lid since
+}
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseDrafting:
+		return "drafting"
+	case PhaseWallTiling:
+		return "wall-tiling"
+	default:
+		return "unknown"
+	}
+}
+
+// phaseFromString parses a phase written by Phase.String
+func phaseFromString(s string) (Phase, bool) {
+	switch s {
+	case "drafting", "":
+		return PhaseDrafting, true
+	case "wall-tiling":
+		return PhaseWallTiling, true
+	default:
+		return PhaseDrafting, false
+	}
+}
+
+// tilesToString writes tiles as color letters
+func tilesToString(tiles []TileColor) string {
+	var sb strings.Builder
+	for _, t := range tiles {
+		sb.WriteString(t.String())
+	}
+	return sb.String()
+}
+
+// tilesFromString parses tiles written by tilesToString
+func tilesFromString(s string) ([]TileColor, error) {
+	tiles := make([]TileColor, 0, len(s))
+	for _, r := range s {
+		if r == '1' {
+			tiles = append(tiles, FirstPlayerMarker)
+			continue
+		}
+		color, ok := ColorFromString(string(r))
+		if !ok {
+			return nil, fmt.Errorf("invalid tile %q", r)
+		}
+		tiles = append(tiles, color)
+	}
+	return tiles, nil
+}
+
+// MarshalJSON writes the game as a versioned save document
+func (g *Game) MarshalJSON() ([]byte, error) {
+	doc := savedGame{
+		Version:       SaveVersion,
+		Variant:       g.Variant.String(),
+		NumPlayers:    g.NumPlayers,
+		Round:         g.Round,
+		Phase:         g.Phase.String(),
+		CurrentPlayer: g.CurrentPlayer,
+		FirstPlayer:   g.FirstPlayer,
+		GameOver:      g.GameOver,
+		Players:       make([]savedPlayer, len(g.Players)),
+		Factories:     make([]string, len(g.Factories)),
+		Center: savedCenter{
+			Tiles:           tilesToString(g.Center.Tiles),
+			FirstPlayerTile: g.Center.HasFirstPlayerTile,
+		},
+		Bag: savedBag{
+			Seed:  g.Bag.seed,
+			RNG:   g.Bag.src.state,
+			Tiles: tilesToString(g.Bag.tiles),
+		},
+		Lid: tilesToString(g.Lid.Tiles),
+	}
+
+	for i, pb := range g.Players {
+		sp := savedPlayer{
+			Score: pb.Score,
+			Floor: tilesToString(pb.FloorLine),
+		}
+		for row := 0; row < 5; row++ {
+			pl := pb.PatternLines[row]
+			sp.Lines[row] = strings.Repeat(pl.Color.String(), pl.Filled)
+
+			var sb strings.Builder
+			for col := 0; col < 5; col++ {
+				if pb.Wall[row][col] {
+					sb.WriteString(pb.WallColor(row, col).String())
+				} else {
+					sb.WriteString(NoTile.String())
+				}
+			}
+			sp.Wall[row] = sb.String()
+		}
+		doc.Players[i] = sp
+	}
+
+	for i, f := range g.Factories {
+		doc.Factories[i] = tilesToString(f.Tiles)
+	}
+
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON restores a game from a save document
+func (g *Game) UnmarshalJSON(data []byte) error {
+	var doc savedGame
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	if doc.Version < 1 || doc.Version > SaveVersion {
+		return fmt.Errorf("unsupported save version %d (expected %d)", doc.Version, SaveVersion)
+	}
+	if doc.NumPlayers < 2 || doc.NumPlayers > 4 || len(doc.Players) != doc.NumPlayers {
+		return fmt.Errorf("invalid player count %d with %d boards", doc.NumPlayers, len(doc.Players))
+	}
+	if len(doc.Factories) != doc.NumPlayers*2+1 {
+		return fmt.Errorf("expected %d factories, got %d", doc.NumPlayers*2+1, len(doc.Factories))
+	}
+	if doc.CurrentPlayer < 0 || doc.CurrentPlayer >= doc.NumPlayers {
+		return fmt.Errorf("invalid current player index: %d", doc.CurrentPlayer)
+	}
+	if doc.FirstPlayer < 0 || doc.FirstPlayer >= doc.NumPlayers {
+		return fmt.Errorf("invalid first player index: %d", doc.FirstPlayer)
+	}
+
+	variant, ok := VariantFromString(doc.Variant)
+	if !ok {
+		return fmt.Errorf("unknown variant %q", doc.Variant)
+	}
+	phase, ok := phaseFromString(doc.Phase)
+	if !ok {
+		return fmt.Errorf("unknown phase %q", doc.Phase)
+	}
+
+	loaded := &Game{
+		Players:       make([]*PlayerBoard, doc.NumPlayers),
+		Factories:     make([]*Factory, len(doc.Factories)),
+		Center:        NewCenter(),
+		Lid:           NewLid(),
+		CurrentPlayer: doc.CurrentPlayer,
+		FirstPlayer:   doc.FirstPlayer,
+		Round:         doc.Round,
+		GameOver:      doc.GameOver,
+		NumPlayers:    doc.NumPlayers,
+		Variant:       variant,
+		Phase:         phase,
+	}
+
+	lid := doc.Lid
+	if doc.Version == 1 {
+		lid = doc.Bag.Discards
+	}
+	lidTiles, err := tilesFromString(lid)
+	if err != nil {
+		return fmt.Errorf("lid: %w", err)
+	}
+	loaded.Lid.Add(lidTiles)
+
+	for i, sp := range doc.Players {
+		pb, err := sp.board(variant, loaded.Lid)
+		if err != nil {
+			return fmt.Errorf("player %d: %w", i+1, err)
+		}
+		loaded.Players[i] = pb
+	}
+
+	for i, s := range doc.Factories {
+		tiles, err := tilesFromString(s)
+		if err != nil {
+			return fmt.Errorf("factory %d: %w", i+1, err)
+		}
+		loaded.Factories[i] = NewFactory()
+		loaded.Factories[i].Fill(tiles)
+	}
+
+	centerTiles, err := tilesFromString(doc.Center.Tiles)
+	if err != nil {
+		return fmt.Errorf("center: %w", err)
+	}
+	loaded.Center.AddTiles(centerTiles)
+	loaded.Center.HasFirstPlayerTile = doc.Center.FirstPlayerTile
+
+	bagTiles, err := tilesFromString(doc.Bag.Tiles)
+	if err != nil {
+		return fmt.Errorf("bag: %w", err)
+	}
+	src, rng := newBagSource(doc.Bag.RNG)
+	loaded.Bag = &Bag{
+		tiles: append(make([]TileColor, 0, 100), bagTiles...),
+		src:   src,
+		rng:   rng,
+		seed:  doc.Bag.Seed,
+	}
+
+	loaded.subscribers = g.subscribers
+	*g = *loaded
+	return nil
+}
+
+// board rebuilds a player board from its saved form
+// Floor tiles beyond the floor line's slots, which version 1 kept, go to the lid.
+func (sp savedPlayer) board(variant Variant, lid *Lid) (*PlayerBoard, error) {
+	pb := NewPlayerBoard()
+	pb.Variant = variant
+	pb.Score = sp.Score
+
+	for row := 0; row < 5; row++ {
+		tiles, err := tilesFromString(sp.Lines[row])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", row+1, err)
+		}
+		if len(tiles) > row+1 {
+			return nil, fmt.Errorf("line %d holds %d tiles", row+1, len(tiles))
+		}
+		for _, t := range tiles {
+			if t != tiles[0] || t == FirstPlayerMarker {
+				return nil, fmt.Errorf("line %d mixes colors", row+1)
+			}
+		}
+		if len(tiles) > 0 {
+			pb.PatternLines[row].Add(tiles[0], len(tiles))
+		}
+
+		if len(sp.Wall[row]) != 5 {
+			return nil, fmt.Errorf("wall row %d must have 5 positions", row+1)
+		}
+		for col, r := range sp.Wall[row] {
+			if r == '.' {
+				continue
+			}
+			color, ok := ColorFromString(string(r))
+			if !ok {
+				return nil, fmt.Errorf("wall row %d: invalid tile %q", row+1, r)
+			}
+			if variant == ColoredWall && WallPattern[row][col] != color {
+				return nil, fmt.Errorf("wall row %d: %s does not belong in column %d", row+1, color.FullName(), col+1)
+			}
+			pb.Wall[row][col] = true
+			pb.WallColors[row][col] = color
+		}
+	}
+
+	floor, err := tilesFromString(sp.Floor)
+	if err != nil {
+		return nil, fmt.Errorf("floor: %w", err)
+	}
+	for _, t := range floor {
+		lid.Add(pb.AddToFloor(t))
+	}
+
+	return pb, nil
+}
+
+// SaveFile writes the game to a JSON file
+func (g *Game) SaveFile(path string) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// LoadFile reads a game saved with SaveFile
+func LoadFile(path string) (*Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Game{}
+	if err := json.Unmarshal(data, g); err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	return g, nil
+}
@@ -1,5 +1,7 @@
 package game
 
+import "fmt"
+
 // TileColor represents the 5 tile colors in Azul
 type TileColor int
 
@@ -57,6 +59,24 @@ func (t TileColor) FullName() string {
 	}
 }
 
+// MarshalText writes the tile's letter, so tiles read well in JSON
+func (t TileColor) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText reads a tile written by MarshalText
+func (t *TileColor) UnmarshalText(text []byte) error {
+	tiles, err := tilesFromString(string(text))
+	if err != nil {
+		return err
+	}
+	if len(tiles) != 1 {
+		return fmt.Errorf("invalid tile %q", text)
+	}
+	*t = tiles[0]
+	return nil
+}
+
 // ColorFromString parses a color from user input
 func ColorFromString(s string) (TileColor, bool) {
 	switch s {
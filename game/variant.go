@@ -0,0 +1,32 @@
+package game
+
+// Variant selects which side of the player boards is used
+type Variant int
+
+const (
+	ColoredWall Variant = iota // Standard game: each color has a fixed wall column
+	GrayWall                   // Free placement: any column, each color once per row and column
+)
+
+func (v Variant) String() string {
+	switch v {
+	case ColoredWall:
+		return "standard"
+	case GrayWall:
+		return "gray"
+	default:
+		return "unknown"
+	}
+}
+
+// VariantFromString parses a variant from user input
+func VariantFromString(s string) (Variant, bool) {
+	switch s {
+	case "standard", "colored", "color", "":
+		return ColoredWall, true
+	case "gray", "grey", "gray-wall", "grey-wall":
+		return GrayWall, true
+	default:
+		return ColoredWall, false
+	}
+}
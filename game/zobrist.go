@@ -0,0 +1,156 @@
+package game
+
+// Zobrist hashing of the public game state
+//
+// Every feature of a position (a count of one color in a factory, a tile on
+// the wall, a player's score, ...) has a random 64-bit key, and a position's
+// hash is the XOR of the keys of its features. Factories, the center and
+// floor lines are hashed as multisets (color counts), so the order tiles
+// arrived in doesn't matter. The bag is hidden information and isn't hashed.
+
+const (
+	maxHashPlayers   = 4
+	maxHashFactories = 9
+	maxHashCount     = 21  // Counts of one color (0-20 tiles)
+	maxHashScore     = 512 // Scores above this share a key
+	hashTileKinds    = NumColors + 1
+)
+
+type zobristTable struct {
+	factory     [maxHashFactories][NumColors][5]uint64
+	center      [NumColors][maxHashCount]uint64
+	firstPlayer uint64 // First player marker still in the center
+	lines       [maxHashPlayers][5][NumColors][6]uint64
+	wall        [maxHashPlayers][5][5][NumColors]uint64
+	floor       [maxHashPlayers][hashTileKinds][maxHashCount]uint64
+	score       [maxHashPlayers][maxHashScore]uint64
+	current     [maxHashPlayers]uint64
+	wallTiling  uint64 // PhaseWallTiling
+	gameOver    uint64
+}
+
+var zobrist = newZobristTable()
+
+// newZobristTable fills the key table from a fixed seed, so hashes are stable
+func newZobristTable() *zobristTable {
+	src := &bagSource{state: 0x5a0b7157}
+	t := &zobristTable{}
+
+	fill := func(keys []uint64) {
+		for i := range keys {
+			keys[i] = src.Uint64()
+		}
+	}
+
+	for f := range t.factory {
+		for c := range t.factory[f] {
+			fill(t.factory[f][c][:])
+		}
+	}
+	for c := range t.center {
+		fill(t.center[c][:])
+	}
+	t.firstPlayer = src.Uint64()
+	for p := 0; p < maxHashPlayers; p++ {
+		for r := 0; r < 5; r++ {
+			for c := 0; c < NumColors; c++ {
+				fill(t.lines[p][r][c][:])
+			}
+			for col := 0; col < 5; col++ {
+				fill(t.wall[p][r][col][:])
+			}
+		}
+		for k := range t.floor[p] {
+			fill(t.floor[p][k][:])
+		}
+		fill(t.score[p][:])
+	}
+	fill(t.current[:])
+	t.wallTiling = src.Uint64()
+	t.gameOver = src.Uint64()
+
+	return t
+}
+
+// colorCounts counts tiles per color; the first player marker is counted last
+func colorCounts(tiles []TileColor) [hashTileKinds]int {
+	var counts [hashTileKinds]int
+	for _, t := range tiles {
+		switch {
+		case t < NumColors:
+			counts[t]++
+		case t == FirstPlayerMarker:
+			counts[NumColors]++
+		}
+	}
+	return counts
+}
+
+// Hash returns a Zobrist hash of the public game state
+// Positions reached by different move orders hash equal. The bag contents and
+// order are not part of the hash.
+func (g *Game) Hash() uint64 {
+	t := zobrist
+	var h uint64
+
+	for f, factory := range g.Factories {
+		if f >= maxHashFactories {
+			break
+		}
+		counts := colorCounts(factory.Tiles)
+		for c := 0; c < NumColors; c++ {
+			if n := counts[c]; n > 0 {
+				h ^= t.factory[f][c][min(n, 4)]
+			}
+		}
+	}
+
+	counts := colorCounts(g.Center.Tiles)
+	for c := 0; c < NumColors; c++ {
+		if n := counts[c]; n > 0 {
+			h ^= t.center[c][min(n, maxHashCount-1)]
+		}
+	}
+	if g.Center.HasFirstPlayerTile {
+		h ^= t.firstPlayer
+	}
+
+	for p, pb := range g.Players {
+		if p >= maxHashPlayers {
+			break
+		}
+		for r, pl := range pb.PatternLines {
+			if pl.Filled > 0 && pl.Color < NumColors {
+				h ^= t.lines[p][r][pl.Color][pl.Filled]
+			}
+			for col := 0; col < 5; col++ {
+				if pb.Wall[r][col] {
+					if color := pb.WallColor(r, col); color < NumColors {
+						h ^= t.wall[p][r][col][color]
+					}
+				}
+			}
+		}
+
+		floor := colorCounts(pb.FloorLine)
+		for k, n := range floor {
+			if n > 0 {
+				h ^= t.floor[p][k][min(n, maxHashCount-1)]
+			}
+		}
+
+		h ^= t.score[p][min(max(pb.Score, 0), maxHashScore-1)]
+	}
+
+	if g.CurrentPlayer >= 0 && g.CurrentPlayer < maxHashPlayers {
+		h ^= t.current[g.CurrentPlayer]
+	}
+	if g.Phase == PhaseWallTiling {
+		h ^= t.wallTiling
+	}
+	if g.GameOver {
+		h ^= t.gameOver
+	}
+
+	return h
+}
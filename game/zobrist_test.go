@@ -0,0 +1,83 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// applyAll plays moves in order, failing the test on an illegal one
+func applyAll(t *testing.T, g *Game, moves ...Move) {
+	t.Helper()
+	for _, m := range moves {
+		if err := g.ApplyMove(m); err != nil {
+			t.Fatalf("%s: %v", FormatMove(m), err)
+		}
+	}
+}
+
+func TestHashTranspositions(t *testing.T) {
+	g := NewGameWithSeed(2, 1)
+
+	// Two picks by the first player from different factories onto different
+	// lines, and a reply by the second player from a third factory
+	first := Move{FactoryIdx: 0, Color: g.Factories[0].Tiles[0], LineIdx: 4}
+	second := Move{FactoryIdx: 1, LineIdx: 3}
+	for _, c := range g.Factories[1].GetColors() {
+		if c != first.Color {
+			second.Color = c
+			break
+		}
+	}
+	if second.Color == first.Color {
+		t.Fatal("factory 2 holds only the color of the first pick")
+	}
+	reply := Move{FactoryIdx: 2, Color: g.Factories[2].Tiles[0], LineIdx: 4}
+
+	a := g.Clone()
+	applyAll(t, a, first, reply, second)
+	b := g.Clone()
+	applyAll(t, b, second, reply, first)
+
+	if a.Hash() != b.Hash() {
+		t.Errorf("the same picks in swapped order hash differently: %x, %x", a.Hash(), b.Hash())
+	}
+
+	c := g.Clone()
+	applyAll(t, c, first, reply)
+	d := g.Clone()
+	applyAll(t, d, second, reply)
+	if c.Hash() == d.Hash() {
+		t.Error("different picks hash equal")
+	}
+	if c.Hash() == g.Hash() {
+		t.Error("the hash doesn't change when tiles are taken")
+	}
+}
+
+func TestHashIgnoresBag(t *testing.T) {
+	g := NewGameWithSeed(3, 1)
+	applyAll(t, g, g.GetValidMoves()[0])
+
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 5; i++ {
+		d := g.Determinize(rng)
+		if d.Hash() != g.Hash() {
+			t.Fatalf("reshuffling the bag changed the hash: %x, %x", d.Hash(), g.Hash())
+		}
+	}
+
+	// Same board, a different bag: only the factory fills came from the seed
+	other := NewGameWithSeed(3, 99)
+	for i, f := range g.Factories {
+		other.Factories[i].Tiles = append([]TileColor(nil), f.Tiles...)
+	}
+	other.Center = g.Center.Clone()
+	other.Players[0] = g.Players[0].Clone()
+	other.CurrentPlayer = g.CurrentPlayer
+	if colorCounts(other.Bag.tiles) == colorCounts(g.Bag.tiles) {
+		t.Fatal("both bags hold the same tiles, so the test proves nothing")
+	}
+	if other.Hash() != g.Hash() {
+		t.Errorf("bags with different contents hash differently: %x, %x", other.Hash(), g.Hash())
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// defaultHints is how many moves 'hint' lists
+const defaultHints = 5
+
+// hintSettings chooses the AI 'hint' consults: the -ai level with the AI options of the game
+type hintSettings struct {
+	level   ai.Difficulty
+	opts    []ai.Option
+	allowed bool   // Rated games give no hints
+	used    func() // Called when hints are shown, if set
+}
+
+// parseHintCommand recognizes 'hint' and 'hint N', returning the number of moves to list
+func parseHintCommand(input string) (int, bool) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || fields[0] != "hint" || len(fields) > 2 {
+		return 0, false
+	}
+	if len(fields) == 1 {
+		return defaultHints, true
+	}
+	n, err := strconv.Atoi(fields[1])
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// showHints asks the AI for the best moves of the player to move and lists them
+func showHints(reader *bufio.Reader, g *game.Game, n int, hints hintSettings) {
+	if !hints.allowed {
+		fmt.Printf("\n  %sNo hints in rated games%s\n", display.Red, display.Reset)
+		waitForEnter(reader)
+		return
+	}
+
+	advisor := ai.NewAIPlayer(hints.level, g.CurrentPlayer, hints.opts...)
+	if hints.level == ai.Hard || hints.level == ai.MCTS {
+		fmt.Printf("\n  %s is thinking...\n", advisor.Name())
+	}
+	analyses := advisor.Analyze(context.Background(), g, n)
+	if hints.used != nil {
+		hints.used()
+	}
+	fmt.Print(renderHints(advisor, analyses))
+	waitForEnter(reader)
+}
+
+// renderHints formats ranked moves with their evaluation, point swing and reasons
+func renderHints(advisor *ai.AIPlayer, analyses []ai.MoveAnalysis) string {
+	var sb strings.Builder
+
+	evalLabel := "eval"
+	if advisor.Difficulty() == ai.MCTS {
+		evalLabel = "reward %"
+	}
+	sb.WriteString(fmt.Sprintf("\n  %sTop moves by %s%s\n", display.Bold, advisor.Name(), display.Reset))
+	sb.WriteString(fmt.Sprintf("  %s     %-6s %-44s %8s %6s%s\n", display.Dim, "Move", "", evalLabel, "swing", display.Reset))
+
+	for i, a := range analyses {
+		swingColor := display.Reset
+		switch {
+		case a.Swing > 0:
+			swingColor = display.Green
+		case a.Swing < 0:
+			swingColor = display.Red
+		}
+		sb.WriteString(fmt.Sprintf("  %2d.  %-6s %-44s %8d %s%+6d%s\n",
+			i+1, game.FormatMove(a.Move), a.Move.String(), a.Eval, swingColor, a.Swing, display.Reset))
+
+		reasons := make([]string, len(a.Why))
+		for j, r := range a.Why {
+			reasons[j] = fmt.Sprintf("%+d %s", r.Value, r.Text)
+		}
+		if len(reasons) > 0 {
+			sb.WriteString(fmt.Sprintf("       %s%s%s\n", display.Dim, strings.Join(reasons, ", "), display.Reset))
+		}
+	}
+
+	return sb.String()
+}
@@ -5,19 +5,73 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/eddiefleurent/azul-ai/ai"
 	"github.com/eddiefleurent/azul-ai/display"
 	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/ratings"
+	"github.com/eddiefleurent/azul-ai/record"
 )
 
+// defaultSavePath is used by the 'save' command when -save isn't given
+const defaultSavePath = "azul-save.json"
+
+// savePath is where the game is written after each move and by 'save'
+var savePath string
+
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "replay":
+			runReplay(os.Args[2:])
+			return
+		case "tournament":
+			runTournament(os.Args[2:])
+			return
+		case "ratings":
+			runRatings(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "join":
+			runJoin(os.Args[2:])
+			return
+		case "engine":
+			runEngine(os.Args[2:])
+			return
+		case "tune":
+			runTune(os.Args[2:])
+			return
+		case "analyze":
+			runAnalyze(os.Args[2:])
+			return
+		}
+	}
+
 	// Command line flags
 	numPlayers := flag.Int("players", 2, "Number of players (2-4)")
-	aiDifficulty := flag.String("ai", "medium", "AI difficulty: easy, medium, hard")
+	aiDifficulty := flag.String("ai", "medium", "AI difficulty: easy, medium, hard, mcts")
+	mctsIterations := flag.Int("mcts-iterations", ai.DefaultMCTSConfig().Iterations, "MCTS playouts per move (0 = time limit only)")
+	thinkTime := flag.Duration("think", 0, "Hard AI time budget per move, e.g. 2s (0 = fixed depth)")
+	searchName := flag.String("search", "auto", "Hard AI search: auto, paranoid, maxn")
+	ttSize := flag.Int("tt", ai.DefaultTTSize, "Hard AI transposition table entries (0 = off)")
+	weightsSpec := flag.String("weights", "", "AI evaluation weights: a preset name or a JSON weights file")
+	mctsTime := flag.Duration("mcts-time", ai.DefaultMCTSConfig().TimeLimit, "MCTS thinking time per move (0 = iterations only)")
 	humanPlayer := flag.Int("human", 1, "Which player is human (1-4), 0 for AI vs AI")
+	seatList := flag.String("seats", "", "Who plays each seat, e.g. human:Alice,hard,human:Bob,medium (overrides -players, -human and -ai)")
+	variantName := flag.String("variant", "standard", "Rules variant: standard, gray")
+	loadPath := flag.String("load", "", "Resume a game saved to this file")
+	flag.StringVar(&savePath, "save", "", "Save the game to this file after every move")
+	rated := flag.Bool("rated", false, "Rated game: disable undo/redo and hints, so the game is sure to count in the ratings")
+	recordDir := flag.String("records", "games", "Directory for game records (empty to disable)")
+	eventsPath := flag.String("events", "", "Log game events to this file as JSON lines")
+	ratingsPath := flag.String("ratings", ratings.DefaultPath, "Ratings file updated after each game played without undo or hints (empty to disable)")
+	humanName := flag.String("name", defaultHumanName(), "Your name in the ratings")
 	showHelp := flag.Bool("help", false, "Show help")
 
 	flag.Parse()
@@ -27,38 +81,134 @@ func main() {
 		return
 	}
 
-	// Parse AI difficulty
-	var difficulty ai.Difficulty
-	switch strings.ToLower(*aiDifficulty) {
-	case "easy":
-		difficulty = ai.Easy
-	case "medium":
-		difficulty = ai.Medium
-	case "hard":
-		difficulty = ai.Hard
-	default:
-		difficulty = ai.Medium
+	// Parse AI difficulty (unknown levels fall back to medium)
+	difficulty, _ := ai.DifficultyFromString(strings.ToLower(*aiDifficulty))
+	searchMode, ok := ai.SearchModeFromString(strings.ToLower(*searchName))
+	if !ok {
+		fmt.Printf("Unknown search mode %q (use auto, paranoid or maxn)\n", *searchName)
+		os.Exit(1)
+	}
+	mctsConfig := ai.DefaultMCTSConfig()
+	mctsConfig.Iterations = *mctsIterations
+	mctsConfig.TimeLimit = *mctsTime
+	aiOpts := []ai.Option{ai.WithMCTS(mctsConfig), ai.WithSearchMode(searchMode), ai.WithThinkTime(*thinkTime), ai.WithTranspositionTable(*ttSize)}
+	if *weightsSpec != "" {
+		weights, err := loadWeights(*weightsSpec)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		aiOpts = append(aiOpts, ai.WithWeights(weights))
+	}
+	hints := hintSettings{level: difficulty, opts: aiOpts, allowed: !*rated}
+
+	variant, ok := game.VariantFromString(strings.ToLower(*variantName))
+	if !ok {
+		fmt.Printf("Unknown variant %q (use standard or gray)\n", *variantName)
+		os.Exit(1)
+	}
+
+	var seats []seatSpec
+	if *seatList != "" {
+		var err error
+		seats, err = parseSeats(*seatList)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		*numPlayers = len(seats)
+	}
+
+	// Round scoring screen and event log, subscribed before the first round is dealt
+	scoring := &roundScoring{}
+	gameOpts := []game.Option{game.WithVariant(variant), game.WithSubscriber(scoring)}
+	var events *eventLog
+	if *eventsPath != "" {
+		var err error
+		events, err = openEventLog(*eventsPath)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer events.Close()
+		gameOpts = append(gameOpts, game.WithSubscriber(events))
 	}
 
-	// Create game
-	g := game.NewGame(*numPlayers)
+	// Create or resume game
+	var g *game.Game
+	if *loadPath != "" {
+		loaded, err := game.LoadFile(*loadPath)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		g = loaded
+		g.Subscribe(scoring)
+		if events != nil {
+			g.Subscribe(events)
+		}
+	} else {
+		g = game.NewGame(*numPlayers, gameOpts...)
+	}
+	if *rated {
+		g.SetUndoAllowed(false)
+	}
 
 	// Use the game's clamped player count (NewGame clamps to 2-4)
 	numPlayersActual := g.NumPlayers
+	if seats == nil {
+		seats = defaultSeats(numPlayersActual, *humanPlayer, difficulty)
+	} else if len(seats) != numPlayersActual {
+		fmt.Printf("Error: -seats lists %d seats but the game has %d players\n", len(seats), numPlayersActual)
+		os.Exit(1)
+	}
 
 	// Player names
 	playerNames := make([]string, numPlayersActual)
-	aiPlayers := make(map[int]*ai.AIPlayer)
-
-	for i := 0; i < numPlayersActual; i++ {
-		if i+1 == *humanPlayer {
-			playerNames[i] = "You"
+	aiLevels := make([]string, numPlayersActual)
+	ratingIDs := make([]string, numPlayersActual)
+	aiPlayers := make(map[int]ai.Player)
+
+	for i, seat := range seats {
+		if seat.human {
+			playerNames[i] = humanLabel(seats, i)
+			if seat.name != "" {
+				ratingIDs[i] = humanRatingID(seat.name)
+			} else if humanCount(seats) == 1 {
+				ratingIDs[i] = humanRatingID(*humanName)
+			} else {
+				ratingIDs[i] = humanRatingID(playerNames[i])
+			}
+		} else if seat.engine != nil {
+			eng, err := startEngine(seat.engine, *thinkTime)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			defer eng.Close()
+			aiPlayers[i] = eng
+			playerNames[i] = eng.Name()
+			aiLevels[i] = "engine"
+			ratingIDs[i] = engineRatingID(eng.Name())
 		} else {
-			aiPlayers[i] = ai.NewAIPlayer(difficulty, i)
-			playerNames[i] = aiPlayers[i].Name()
+			p := ai.NewAIPlayer(seat.level, i, aiOpts...)
+			aiPlayers[i] = p
+			playerNames[i] = p.Name()
+			aiLevels[i] = seat.level.String()
+			ratingIDs[i] = p.Identity()
 		}
 	}
 
+	// Hot-seat play: humans sharing the terminal get a handoff screen
+	hotSeat := humanCount(seats) > 1
+	lastHuman := -1
+
+	// Undo and hints make a human's result meaningless for the ratings
+	assisted := false
+	hints.used = func() { assisted = true }
+
+	rec := record.New(g, playerNames, aiLevels)
+
 	reader := bufio.NewReader(os.Stdin)
 
 	// Main game loop
@@ -76,13 +226,42 @@ func main() {
 			// AI's turn - show game state
 			fmt.Print(display.RenderGame(g, playerNames))
 			fmt.Printf("\n%s is thinking...\n", aiPlayer.Name())
+			searcher, hasTT := aiPlayer.(*ai.AIPlayer)
+			var before ai.TTStats
+			if hasTT {
+				before = searcher.TTStats()
+			}
 			selectedMove = aiPlayer.ChooseMove(g, moves)
-			fmt.Printf("%s chose: %s\n", aiPlayer.Name(), selectedMove.String())
+			fmt.Printf("%s chose: %s (%s)\n", aiPlayer.Name(), game.FormatMove(selectedMove), selectedMove.String())
+			if hasTT {
+				if stats := searcher.TTStats(); stats.Probes > before.Probes {
+					fmt.Printf("%sTransposition table: %s%s\n", display.Dim, stats, display.Reset)
+				}
+			}
 			fmt.Println("\nPress Enter to continue...")
 			reader.ReadString('\n')
 		} else {
-			// Human's turn - interactive selection (shows game state internally)
-			selectedMove = getHumanMoveInteractive(reader, g, playerNames)
+			if hotSeat && g.CurrentPlayer != lastHuman {
+				fmt.Print(display.RenderHandoff(playerNames[g.CurrentPlayer]))
+				waitForEnter(reader)
+			}
+			lastHuman = g.CurrentPlayer
+
+			var ok bool
+			if g.Phase == game.PhaseWallTiling {
+				// Human's Gray Wall placement
+				selectedMove, ok = getHumanWallTilingMove(reader, g, playerNames, moves, hints)
+			} else {
+				// Human's turn - interactive selection (shows game state internally)
+				selectedMove, ok = getHumanMoveInteractive(reader, g, playerNames, hints)
+			}
+			if !ok {
+				// Position changed by undo/redo - start the turn over
+				assisted = true
+				scoring.sync(g)
+				autosave(g)
+				continue
+			}
 		}
 
 		// Apply the move
@@ -90,14 +269,78 @@ func main() {
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			reader.ReadString('\n')
+		} else {
+			autosave(g)
+			if summary := scoring.take(); summary != nil {
+				fmt.Print(display.RenderRoundSummary(summary, playerNames))
+				if !g.GameOver {
+					fmt.Println()
+					waitForEnter(reader)
+				}
+			}
 		}
 	}
 
 	// Game over
 	fmt.Print(display.RenderGameOver(g, playerNames))
+
+	if g.GameOver && *recordDir != "" {
+		rec.Finish(g)
+		path, err := saveRecord(rec, *recordDir)
+		if err != nil {
+			fmt.Printf("Error saving game record: %v\n", err)
+		} else {
+			fmt.Printf("\nGame record saved to %s (watch it with: azul-ai replay %s)\n", path, path)
+		}
+	}
+
+	// Every finished game counts unless a human took back moves or asked for hints
+	if g.GameOver && *ratingsPath != "" {
+		switch {
+		case humanCount(seats) > 0 && assisted:
+			fmt.Printf("Not rated: undo or hints were used\n")
+		case humanCount(seats) > 0 && *loadPath != "":
+			fmt.Printf("Not rated: the game was resumed, so undo and hints can't be ruled out\n")
+		default:
+			if err := rateGame(*ratingsPath, ratingIDs, g); err != nil {
+				fmt.Printf("Error updating ratings: %v\n", err)
+			} else {
+				fmt.Printf("Ratings updated (see them with: azul-ai ratings)\n")
+			}
+		}
+	}
+}
+
+// loadWeights returns the weight preset with the given name, or loads a weights file
+func loadWeights(spec string) (ai.EvalWeights, error) {
+	if w, ok := ai.WeightPreset(spec); ok {
+		return w, nil
+	}
+	return ai.LoadWeights(spec)
+}
+
+// saveRecord writes a finished game's record to a timestamped file in dir
+func saveRecord(rec *record.Record, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "azul-"+rec.Header.Date.Format("20060102-150405")+".json")
+	return path, rec.Save(path)
+}
+
+// autosave writes the game to the -save file, if one was given
+func autosave(g *game.Game) {
+	if savePath == "" {
+		return
+	}
+	if err := g.SaveFile(savePath); err != nil {
+		fmt.Printf("Error saving game: %v\n", err)
+	}
 }
 
-func getHumanMoveInteractive(reader *bufio.Reader, g *game.Game, playerNames []string) game.Move {
+// getHumanMoveInteractive walks a human through choosing a move
+// Returns false if the position changed (undo/redo) and the turn must restart
+func getHumanMoveInteractive(reader *bufio.Reader, g *game.Game, playerNames []string, hints hintSettings) (game.Move, bool) {
 	player := g.Players[g.CurrentPlayer]
 
 	// Step 1: Choose source - show full game state first
@@ -106,12 +349,25 @@ func getHumanMoveInteractive(reader *bufio.Reader, g *game.Game, playerNames []s
 		// Show full game state
 		fmt.Print(display.RenderGame(g, playerNames))
 		fmt.Print(display.RenderSourceSelection(g))
-		fmt.Print("\n  Enter number (or 'q' to quit, 'h' for help): ")
+		fmt.Print("\n  Enter number or move like 3b2 (or 'q' to quit, 'h' for help" + undoHint(g) + "): ")
 
 		input := readInput(reader)
-		if handleSpecialInput(input) {
+		if handleHistoryInput(reader, input, g) {
+			return game.Move{}, false
+		}
+		if handleSpecialInput(reader, input, g, hints) {
 			continue
 		}
+		if len(input) >= 3 {
+			// Move notation shortcut, e.g. 3b2
+			move, err := notationMove(g, input)
+			if err != nil {
+				fmt.Printf("\n  %s%v%s\n", display.Red, err, display.Reset)
+				waitForEnter(reader)
+				continue
+			}
+			return move, true
+		}
 
 		sources := display.GetAvailableSources(g)
 		num, err := strconv.Atoi(input)
@@ -136,9 +392,9 @@ func getHumanMoveInteractive(reader *bufio.Reader, g *game.Game, playerNames []s
 
 		input := readInput(reader)
 		if input == "b" || input == "back" {
-			return getHumanMoveInteractive(reader, g, playerNames) // Start over
+			return getHumanMoveInteractive(reader, g, playerNames, hints) // Start over
 		}
-		if handleSpecialInput(input) {
+		if handleSpecialInput(reader, input, g, hints) {
 			continue
 		}
 
@@ -190,9 +446,9 @@ func getHumanMoveInteractive(reader *bufio.Reader, g *game.Game, playerNames []s
 
 		input := readInput(reader)
 		if input == "b" || input == "back" {
-			return getHumanMoveInteractive(reader, g, playerNames) // Start over
+			return getHumanMoveInteractive(reader, g, playerNames, hints) // Start over
 		}
-		if handleSpecialInput(input) {
+		if handleSpecialInput(reader, input, g, hints) {
 			continue
 		}
 
@@ -211,7 +467,128 @@ func getHumanMoveInteractive(reader *bufio.Reader, g *game.Game, playerNames []s
 		FactoryIdx: sourceIdx,
 		Color:      selectedColor,
 		LineIdx:    lineIdx,
+	}, true
+}
+
+// getHumanWallTilingMove asks where a full pattern line goes on a Gray Wall
+// Returns false if the position changed (undo/redo) and the turn must restart
+func getHumanWallTilingMove(reader *bufio.Reader, g *game.Game, playerNames []string, moves []game.Move, hints hintSettings) (game.Move, bool) {
+	player := g.Players[g.CurrentPlayer]
+	row := moves[0].LineIdx
+	color := moves[0].Color
+
+	columns := make([]int, len(moves))
+	for i, m := range moves {
+		columns[i] = m.Column
+	}
+
+	for {
+		fmt.Print(display.RenderGame(g, playerNames))
+		fmt.Print(display.RenderWallTilingSelection(player, row, color, columns))
+		fmt.Print("\n  Enter number (or 'q' to quit, 'h' for help" + undoHint(g) + "): ")
+
+		input := readInput(reader)
+		if handleHistoryInput(reader, input, g) {
+			return game.Move{}, false
+		}
+		if handleSpecialInput(reader, input, g, hints) {
+			continue
+		}
+		if len(input) >= 3 {
+			// Move notation shortcut, e.g. 3b2
+			move, err := notationMove(g, input)
+			if err != nil {
+				fmt.Printf("\n  %s%v%s\n", display.Red, err, display.Reset)
+				waitForEnter(reader)
+				continue
+			}
+			return move, true
+		}
+
+		num, err := strconv.Atoi(input)
+		if err != nil || num < 1 || num > len(moves) {
+			fmt.Printf("\n  %sInvalid choice. Enter 1-%d%s\n", display.Red, len(moves), display.Reset)
+			waitForEnter(reader)
+			continue
+		}
+
+		return moves[num-1], true
+	}
+}
+
+// notationMove parses a move typed in notation and checks that it is legal
+func notationMove(g *game.Game, input string) (game.Move, error) {
+	move, err := game.ParseMove(input)
+	if err != nil {
+		return game.Move{}, err
+	}
+	for _, m := range g.GetValidMoves() {
+		if m == move {
+			return move, nil
+		}
+	}
+	return game.Move{}, fmt.Errorf("%s is not a legal move", game.FormatMove(move))
+}
+
+// undoHint lists the undo/redo commands currently available
+func undoHint(g *game.Game) string {
+	hint := ""
+	if g.CanUndo() {
+		hint += ", 'u' to undo"
+	}
+	if g.CanRedo() {
+		hint += ", 'r' to redo"
 	}
+	return hint
+}
+
+// hasMoved returns true if the seat has a move in the game history
+func hasMoved(g *game.Game, seat int) bool {
+	for _, entry := range g.History() {
+		if entry.Player == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// handleHistoryInput handles 'u'ndo and 'r'edo at a human's prompt
+// Undo goes back to this player's previous turn, taking back the moves made
+// since; redo replays them. Returns true if the position changed.
+func handleHistoryInput(reader *bufio.Reader, input string, g *game.Game) bool {
+	seat := g.CurrentPlayer
+
+	switch input {
+	case "u", "undo":
+		if !g.CanUndo() || !hasMoved(g, seat) {
+			fmt.Printf("\n  %sNothing to undo%s\n", display.Red, display.Reset)
+			waitForEnter(reader)
+			return false
+		}
+		for {
+			entry, ok := g.Undo()
+			if !ok || entry.Player == seat {
+				break
+			}
+		}
+		return true
+	case "r", "redo":
+		if !g.CanRedo() {
+			fmt.Printf("\n  %sNothing to redo%s\n", display.Red, display.Reset)
+			waitForEnter(reader)
+			return false
+		}
+		g.Redo()
+		for {
+			next, ok := g.NextRedo()
+			if !ok || next.Player == seat {
+				break
+			}
+			g.Redo()
+		}
+		return true
+	}
+	return false
 }
 
 func waitForEnter(reader *bufio.Reader) {
@@ -224,7 +601,12 @@ func readInput(reader *bufio.Reader) string {
 	return strings.TrimSpace(strings.ToLower(input))
 }
 
-func handleSpecialInput(input string) bool {
+func handleSpecialInput(reader *bufio.Reader, input string, g *game.Game, hints hintSettings) bool {
+	if n, ok := parseHintCommand(input); ok {
+		showHints(reader, g, n, hints)
+		return true
+	}
+
 	switch input {
 	case "q", "quit":
 		fmt.Println("\nThanks for playing!")
@@ -232,6 +614,18 @@ func handleSpecialInput(input string) bool {
 	case "h", "help":
 		printHelp()
 		return true
+	case "save":
+		path := savePath
+		if path == "" {
+			path = defaultSavePath
+		}
+		if err := g.SaveFile(path); err != nil {
+			fmt.Printf("\n  %sError saving game: %v%s\n", display.Red, err, display.Reset)
+		} else {
+			fmt.Printf("\n  %sGame saved to %s%s\n", display.Green, path, display.Reset)
+		}
+		waitForEnter(reader)
+		return true
 	}
 	return false
 }
@@ -251,6 +645,12 @@ func printHelp() {
   2. Pick a color from that source (you take ALL tiles of that color)
   3. Pick a pattern line to place them on
 
+  Or type the move directly in notation: source, color, line.
+    3b2   take Blue from factory 3, place on line 2
+    cy-   take Yellow from the center, place on the floor
+  Colors: b=Blue y=Yellow r=Red k=Black w=White. Gray Wall placements
+  are written line, color, @column: 2b@3
+
 ` + display.Bold + `PATTERN LINES:` + display.Reset + `
   - The 5 rows on the left (sizes 1-5)
   - Each line can only hold ONE color
@@ -271,6 +671,11 @@ func printHelp() {
   - Complete vertical line: +7 bonus
   - All 5 of one color: +10 bonus
 
+` + display.Bold + `GRAY WALL VARIANT:` + display.Reset + `
+  - Completed lines may go in ANY empty column of their wall row
+  - Each color only once per row AND once per column
+  - A line with no legal column goes to the floor
+
 ` + display.Bold + `GAME END:` + display.Reset + `
   The game ends when any player completes a horizontal wall row.
 
@@ -278,14 +683,72 @@ func printHelp() {
   - Enter a number to select
   - 'b' to go back a step
   - 'h' for this help
+  - 'u' to undo back to your previous turn, 'r' to redo
+  - 'hint' (or 'hint N') to see the AI's top moves and why
+  - 'save' to save the game (resume later with -load)
   - 'q' to quit
 
 ` + display.Bold + `COMMAND LINE OPTIONS:` + display.Reset + `
   -players N    Number of players (2-4), default 2
-  -ai LEVEL     AI difficulty: easy, medium, hard (default medium)
+  -ai LEVEL     AI difficulty: easy, medium, hard, mcts (default medium)
+  -think D      Hard AI time budget per move, e.g. 2s; searches deeper
+                and deeper until time runs out (default: fixed depth)
+  -search MODE  Hard AI search: auto, paranoid, maxn (default auto:
+                paranoid for 2 players, max-n for 3-4)
+  -tt N         Hard AI transposition table entries (default 131072,
+                0 = off)
+  -weights W    AI evaluation weights: a preset (default) or a JSON
+                weights file
+  -mcts-iterations N  MCTS playouts per move (default 3000)
+  -mcts-time D        MCTS thinking time per move, e.g. 2s (default 3s)
   -human N      Which player is human (1-4), 0 for AI vs AI
+  -seats LIST   Who plays each seat, e.g. human:Alice,hard,human:Bob,medium
+                (overrides -players, -human and -ai); several humans
+                share the terminal and hand it over between turns;
+                engine:COMMAND seats an external engine
+  -variant V    Rules variant: standard, gray (default standard)
+  -save FILE    Save the game to FILE after every move
+  -load FILE    Resume a saved game
+  -rated        Rated game: undo/redo and hints disabled, so the
+                result is sure to go into the ratings
+  -records DIR  Where finished games are recorded (default games)
+  -events FILE  Log game events (moves, wall tiles, penalties, round and
+                game ends) to FILE as JSON lines
+  -ratings FILE Ratings file updated after each game played without
+                undo or hints (default ratings.json)
+  -name NAME    Your name in the ratings (default $USER)
   -help         Show this help
 
+` + display.Bold + `REPLAY:` + display.Reset + `
+  azul-ai replay FILE   Step through a recorded game
+  azul-ai analyze FILE  Report the inaccuracies and blunders of the humans
+                        in a recorded game (Markdown, -o to write a file)
+
+` + display.Bold + `TOURNAMENT:` + display.Reset + `
+  azul-ai tournament -games 200 -ai hard,medium
+                        Play AI-vs-AI games without rendering and report
+                        win rates, scores and Elo ratings
+                        (azul-ai tournament -help lists the options)
+
+` + display.Bold + `RATINGS:` + display.Reset + `
+  azul-ai ratings       Leaderboard of Glicko-2 ratings from finished games
+                        and tournaments
+
+` + display.Bold + `NETWORK PLAY:` + display.Reset + `
+  azul-ai serve -players 3      Host a game on port 7777; AIs take the
+                                seats nobody joins within 2 minutes
+  azul-ai join -addr HOST:7777  Join a hosted game
+
+` + display.Bold + `ENGINES:` + display.Reset + `
+  azul-ai engine -ai hard       Play as an engine over stdin/stdout, for
+                                other programs (see engine/protocol.go)
+  -seats human,engine:./mybot   Play against an external engine
+
+` + display.Bold + `TUNING:` + display.Reset + `
+  azul-ai tune -iterations 100  Tune the evaluation weights by self-play
+                                against a baseline (see: azul-ai tune -h)
+  -weights tuned-weights.json   Play with the tuned weights
+
 `
 	fmt.Println(help)
 }
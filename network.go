@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/server"
+)
+
+// defaultServerAddr is the address used by serve and join unless told otherwise
+const defaultServerAddr = ":7777"
+
+// runServe implements `azul-ai serve`: host a game for players on other machines
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", defaultServerAddr, "Address to listen on")
+	numPlayers := fs.Int("players", 2, "Number of seats (2-4)")
+	wait := fs.Duration("wait", 2*time.Minute, "How long to wait for players before AIs fill the empty seats (0 = until every seat is taken)")
+	aiDifficulty := fs.String("ai", "medium", "Level of the AIs in empty seats: easy, medium, hard, mcts")
+	variantName := fs.String("variant", "standard", "Rules variant: standard, gray")
+	fs.Usage = func() {
+		fmt.Println("Usage: azul-ai serve [options]")
+		fmt.Println("  Host a game; players connect with: azul-ai join -addr HOST:PORT")
+		fmt.Println()
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	difficulty, ok := ai.DifficultyFromString(strings.ToLower(*aiDifficulty))
+	if !ok {
+		fmt.Printf("Unknown AI level %q (use easy, medium, hard or mcts)\n", *aiDifficulty)
+		os.Exit(1)
+	}
+	variant, ok := game.VariantFromString(strings.ToLower(*variantName))
+	if !ok {
+		fmt.Printf("Unknown variant %q (use standard or gray)\n", *variantName)
+		os.Exit(1)
+	}
+
+	srv, err := server.Listen(*addr, server.Config{
+		Players: *numPlayers,
+		Variant: variant,
+		Wait:    *wait,
+		AI:      difficulty,
+		Logf:    log.Printf,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer srv.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Printf("Hosting a %d-player %s game on %s", *numPlayers, variant, srv.Addr())
+	g, names, err := srv.Run(ctx)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		os.Exit(1)
+	}
+	fmt.Print(display.RenderGameOver(g, names))
+}
+
+// runJoin implements `azul-ai join`: play in a game hosted with serve
+func runJoin(args []string) {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	addr := fs.String("addr", "localhost"+defaultServerAddr, "Server address (HOST:PORT)")
+	name := fs.String("name", defaultHumanName(), "Your name at the table")
+	fs.Usage = func() {
+		fmt.Println("Usage: azul-ai join [options]")
+		fmt.Println("  Join a game hosted with azul-ai serve")
+		fmt.Println()
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	conn, err := server.Dial(*addr)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	if err := conn.Send(server.Message{Type: server.MsgJoin, Name: *name}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	seat := -1
+	var state *server.Message // Last position where it was our turn
+	var names []string        // Seat names from the last message that had them
+	scoring := &roundScoring{}
+	hints := hintSettings{level: ai.Medium, allowed: true}
+
+	for {
+		m, err := conn.Receive()
+		if err != nil {
+			fmt.Printf("\nDisconnected: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(m.Names) > 0 {
+			names = m.Names
+		}
+
+		switch m.Type {
+		case server.MsgWelcome:
+			seat = m.Seat
+			fmt.Printf("Joined %s as player %d. Waiting for the game to start...\n", *addr, seat+1)
+		case server.MsgInfo:
+			fmt.Printf("%s\n", m.Text)
+		case server.MsgState:
+			if m.Game == nil {
+				continue
+			}
+			names := tableNames(m.Names, seat)
+			if len(m.Moves) == 0 {
+				fmt.Print(display.RenderGame(m.Game, names))
+				fmt.Printf("\nWaiting for %s...\n", names[m.Seat])
+				continue
+			}
+			state = &m
+			sendNetworkMove(conn, reader, state, seat, hints)
+		case server.MsgMoved:
+			if m.Seat != seat {
+				fmt.Printf("Player %d played %s\n", m.Seat+1, m.Move)
+			}
+		case server.MsgEvent:
+			e, err := game.DecodeEvent(m.Event, m.Data)
+			if err != nil {
+				continue // From a newer server
+			}
+			scoring.OnEvent(e)
+			if summary := scoring.take(); summary != nil {
+				fmt.Print(display.RenderRoundSummary(summary, tableNames(names, seat)))
+				fmt.Println()
+				waitForEnter(reader)
+			}
+		case server.MsgError:
+			fmt.Printf("\n%s%s%s\n", display.Red, m.Text, display.Reset)
+			if seat < 0 {
+				os.Exit(1) // Turned away
+			}
+			if state != nil {
+				waitForEnter(reader)
+				sendNetworkMove(conn, reader, state, seat, hints)
+			}
+		case server.MsgGameOver:
+			if m.Game != nil {
+				fmt.Print(display.RenderGameOver(m.Game, tableNames(m.Names, seat)))
+			}
+			return
+		}
+	}
+}
+
+// sendNetworkMove asks for a move in the given position and sends it to the server
+// The position is a copy, so the usual interactive prompts work on it directly
+func sendNetworkMove(conn *server.Conn, reader *bufio.Reader, state *server.Message, seat int, hints hintSettings) {
+	g := state.Game
+	names := tableNames(state.Names, seat)
+
+	var move game.Move
+	if g.Phase == game.PhaseWallTiling {
+		move, _ = getHumanWallTilingMove(reader, g, names, g.GetValidMoves(), hints)
+	} else {
+		move, _ = getHumanMoveInteractive(reader, g, names, hints)
+	}
+
+	if err := conn.Send(server.Message{Type: server.MsgMove, Seat: seat, Move: game.FormatMove(move)}); err != nil {
+		fmt.Printf("\nDisconnected: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("\nMove sent...")
+}
+
+// tableNames labels the seats for display, marking our own
+func tableNames(names []string, seat int) []string {
+	labels := make([]string, len(names))
+	for i, name := range names {
+		switch {
+		case name == "":
+			labels[i] = fmt.Sprintf("Player %d", i+1)
+		case i == seat:
+			labels[i] = name + " (you)"
+		default:
+			labels[i] = name
+		}
+	}
+	return labels
+}
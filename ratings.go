@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/ratings"
+)
+
+// runRatings implements `azul-ai ratings`: print the leaderboard
+func runRatings(args []string) {
+	fs := flag.NewFlagSet("ratings", flag.ExitOnError)
+	path := fs.String("file", ratings.DefaultPath, "Ratings file")
+	minGames := fs.Int("min-games", 1, "Only list players with at least this many games")
+	fs.Usage = func() {
+		fmt.Println("Usage: azul-ai ratings [options]")
+		fmt.Println("  Print the Glicko-2 leaderboard of players and AI configurations")
+		fmt.Println()
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	store, err := ratings.Load(*path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	entries := store.Leaderboard(*minGames)
+	if len(entries) == 0 {
+		fmt.Printf("No rated games in %s yet\n", *path)
+		return
+	}
+	fmt.Print(renderLeaderboard(entries))
+}
+
+// renderLeaderboard formats leaderboard entries as a table
+func renderLeaderboard(entries []ratings.Entry) string {
+	var sb strings.Builder
+
+	sb.WriteString(display.Bold)
+	sb.WriteString(fmt.Sprintf("%4s  %-32s %6s %5s %6s  %-12s %s",
+		"Rank", "Player", "Rating", "±", "Games", "W-D-L", "Last game"))
+	sb.WriteString(display.Reset + "\n")
+
+	for i, e := range entries {
+		sb.WriteString(fmt.Sprintf("%4d  %-32s %6.0f %5.0f %6d  %-12s %s\n",
+			i+1, e.ID, e.Rating.Rating, 2*e.Deviation, e.Games,
+			fmt.Sprintf("%d-%d-%d", e.Wins, e.Draws, e.Losses),
+			e.Updated.Format("2006-01-02 15:04")))
+	}
+
+	sb.WriteString(display.Dim + "± is twice the rating deviation (about a 95% interval)" + display.Reset + "\n")
+	return sb.String()
+}
+
+// rateGame records a finished game in the ratings file at path
+// ids holds the rating identity of each seat
+func rateGame(path string, ids []string, g *game.Game) error {
+	store, err := ratings.Load(path)
+	if err != nil {
+		return err
+	}
+
+	scores := make([]int, len(g.Players))
+	rows := make([]int, len(g.Players))
+	for i, p := range g.Players {
+		scores[i] = p.Score
+		rows[i] = p.CompletedRows()
+	}
+	store.RecordGame(ids, scores, rows, g.GetWinner())
+
+	return store.Save(path)
+}
+
+// humanRatingID returns the rating identity of a human player
+func humanRatingID(name string) string {
+	return "human:" + name
+}
+
+// defaultHumanName names the human player for ratings when -name isn't given
+func defaultHumanName() string {
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "player"
+}
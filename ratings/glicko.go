@@ -0,0 +1,104 @@
+package ratings
+
+import "math"
+
+// Glicko-2 constants (see Glickman, "Example of the Glicko-2 system")
+const (
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+
+	glickoScale = 173.7178 // Converts between the Glicko and Glicko-2 scales
+	tau         = 0.5      // Constrains how fast volatility changes
+	epsilon     = 0.000001 // Convergence tolerance of the volatility iteration
+)
+
+// opponent is one result of a rating period
+type opponent struct {
+	rating    float64
+	deviation float64
+	score     float64 // 1 win, 0.5 draw, 0 loss
+}
+
+// glickoG weights a result by the opponent's rating deviation (on the Glicko-2 scale)
+func glickoG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// glickoE is the expected score against an opponent (on the Glicko-2 scale)
+func glickoE(mu, muJ, phiJ float64) float64 {
+	return 1 / (1 + math.Exp(-glickoG(phiJ)*(mu-muJ)))
+}
+
+// update applies one rating period's results to a rating
+// Opponents are taken at their ratings from before the period
+func update(r Rating, results []opponent) Rating {
+	mu := (r.Rating - DefaultRating) / glickoScale
+	phi := r.Deviation / glickoScale
+	sigma := r.Volatility
+
+	if len(results) == 0 {
+		// Only the uncertainty grows
+		r.Deviation = math.Min(math.Sqrt(phi*phi+sigma*sigma)*glickoScale, DefaultDeviation)
+		return r
+	}
+
+	var vInv, delta float64
+	for _, o := range results {
+		muJ := (o.rating - DefaultRating) / glickoScale
+		phiJ := o.deviation / glickoScale
+		g := glickoG(phiJ)
+		e := glickoE(mu, muJ, phiJ)
+		vInv += g * g * e * (1 - e)
+		delta += g * (o.score - e)
+	}
+	v := 1 / vInv
+	delta *= v
+
+	sigma = newVolatility(phi, sigma, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phiNew := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	muNew := mu + phiNew*phiNew*delta/v
+
+	r.Rating = muNew*glickoScale + DefaultRating
+	r.Deviation = phiNew * glickoScale
+	r.Volatility = sigma
+	return r
+}
+
+// newVolatility finds the new volatility with the Illinois algorithm
+func newVolatility(phi, sigma, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-d)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
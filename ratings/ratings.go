@@ -0,0 +1,210 @@
+package ratings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// Version is the version written into ratings files
+const Version = 1
+
+// DefaultPath is where ratings are kept unless told otherwise
+const DefaultPath = "ratings.json"
+
+// Rating is the Glicko-2 rating of one player identity
+// Identities are AI configurations ("hard think=2s") or humans ("human:alice")
+type Rating struct {
+	Rating     float64   `json:"rating"`
+	Deviation  float64   `json:"deviation"` // Rating deviation (RD)
+	Volatility float64   `json:"volatility"`
+	Games      int       `json:"games"`
+	Wins       int       `json:"wins"`
+	Draws      int       `json:"draws"` // Shared victories
+	Losses     int       `json:"losses"`
+	Updated    time.Time `json:"updated"`
+}
+
+// newRating is the rating of an identity that hasn't played yet
+func newRating() Rating {
+	return Rating{Rating: DefaultRating, Deviation: DefaultDeviation, Volatility: DefaultVolatility}
+}
+
+// Store is a flat file of ratings keyed by identity
+type Store struct {
+	Version int                `json:"version"`
+	Players map[string]*Rating `json:"players"`
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{Version: Version, Players: make(map[string]*Rating)}
+}
+
+// Load reads a ratings file, returning an empty store if it doesn't exist yet
+func Load(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewStore(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewStore()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	if s.Version < 1 || s.Version > Version {
+		return nil, fmt.Errorf("unsupported ratings version %d (expected %d)", s.Version, Version)
+	}
+	if s.Players == nil {
+		s.Players = make(map[string]*Rating)
+	}
+	return s, nil
+}
+
+// Save writes the store to path
+// The file is replaced in one step, so a crash can't leave it half written
+func (s *Store) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".ratings-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get returns an identity's rating (the starting rating if it hasn't played)
+func (s *Store) Get(id string) Rating {
+	if r, ok := s.Players[id]; ok {
+		return *r
+	}
+	return newRating()
+}
+
+// RecordGame updates the ratings of everyone in a finished game
+//
+// ids, scores and rows (completed wall rows, the tiebreak) are per seat, and
+// winner is the winning seat, -1 for a shared victory.
+// The game is one Glicko-2 rating period in which every seat played every
+// other seat: the winner beat everyone, and the rest are ranked by score,
+// then by completed rows. Seats with the same identity (self-play) don't
+// rate each other.
+func (s *Store) RecordGame(ids []string, scores, rows []int, winner int) {
+	if len(ids) != len(scores) || len(ids) != len(rows) {
+		return
+	}
+
+	before := make([]Rating, len(ids))
+	for i, id := range ids {
+		before[i] = s.Get(id)
+	}
+
+	leaders := game.Leaders(scores, rows)
+
+	now := time.Now()
+	updated := make(map[string]bool)
+	for i, id := range ids {
+		if updated[id] {
+			continue // Self-play: rated once, from the first seat
+		}
+		updated[id] = true
+
+		var results []opponent
+		for j := range ids {
+			if ids[j] == id {
+				continue
+			}
+			results = append(results, opponent{
+				rating:    before[j].Rating,
+				deviation: before[j].Deviation,
+				score:     pairScore(scores, rows, winner, i, j),
+			})
+		}
+		if len(results) == 0 {
+			continue // Nobody else to be rated against
+		}
+
+		r := update(before[i], results)
+		r.Games++
+		switch {
+		case i == winner:
+			r.Wins++
+		case winner == -1 && slices.Contains(leaders, i):
+			r.Draws++
+		default:
+			r.Losses++
+		}
+		r.Updated = now
+		s.Players[id] = &r
+	}
+}
+
+// pairScore is seat a's result against seat b: 1 win, 0.5 draw, 0 loss
+func pairScore(scores, rows []int, winner, a, b int) float64 {
+	switch {
+	case winner == a:
+		return 1
+	case winner == b:
+		return 0
+	}
+	switch c := game.CompareResults(scores, rows, a, b); {
+	case c > 0:
+		return 1
+	case c < 0:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// Entry is one line of the leaderboard
+type Entry struct {
+	ID string
+	Rating
+}
+
+// Leaderboard returns every identity with at least minGames games, best first
+// Identities are ranked by rating, then by deviation (the surer rating first)
+func (s *Store) Leaderboard(minGames int) []Entry {
+	var entries []Entry
+	for id, r := range s.Players {
+		if r.Games >= minGames {
+			entries = append(entries, Entry{ID: id, Rating: *r})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Rating.Rating != b.Rating.Rating {
+			return a.Rating.Rating > b.Rating.Rating
+		}
+		if a.Deviation != b.Deviation {
+			return a.Deviation < b.Deviation
+		}
+		return a.ID < b.ID
+	})
+	return entries
+}
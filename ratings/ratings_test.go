@@ -0,0 +1,20 @@
+package ratings
+
+import "testing"
+
+func TestWinRaisesRating(t *testing.T) {
+	s := NewStore()
+	s.RecordGame([]string{"a", "b"}, []int{50, 30}, []int{1, 0}, 0)
+
+	start := newRating()
+	winner, loser := s.Get("a"), s.Get("b")
+	if winner.Rating <= start.Rating || loser.Rating >= start.Rating {
+		t.Errorf("ratings %.0f and %.0f after a win from %.0f", winner.Rating, loser.Rating, start.Rating)
+	}
+	if winner.Deviation >= start.Deviation || loser.Deviation >= start.Deviation {
+		t.Errorf("deviations %.0f and %.0f after a game from %.0f", winner.Deviation, loser.Deviation, start.Deviation)
+	}
+	if winner.Wins != 1 || loser.Losses != 1 || winner.Games != 1 || loser.Games != 1 {
+		t.Errorf("records %+v and %+v, want a win and a loss", winner, loser)
+	}
+}
@@ -0,0 +1,129 @@
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// Version is the version written into game records
+const Version = 1
+
+// Header describes how a recorded game was set up
+type Header struct {
+	Date    time.Time `json:"date"`
+	Seed    int64     `json:"seed,string"`
+	Players int       `json:"players"`
+	Variant string    `json:"variant"`
+	Names   []string  `json:"names"`
+	AI      []string  `json:"ai"` // AI level per seat, "" for humans
+}
+
+// Record is a complete game: header, starting position and move list
+type Record struct {
+	Version int        `json:"version"`
+	Header  Header     `json:"header"`
+	Start   *game.Game `json:"start"`  // Position before the first recorded move
+	Moves   []string   `json:"moves"`  // Moves in standard notation
+	Scores  []int      `json:"scores"` // Final scores, once the game is over
+	Winner  int        `json:"winner"` // Winning seat, -1 for a tie or unfinished game
+
+	offset int // History entries that predate the record
+}
+
+// New starts a record for a game from its current position
+// levels holds the AI level of each seat, "" for humans
+func New(g *game.Game, names []string, levels []string) *Record {
+	return &Record{
+		Version: Version,
+		Header: Header{
+			Date:    time.Now(),
+			Seed:    g.Bag.Seed(),
+			Players: g.NumPlayers,
+			Variant: g.Variant.String(),
+			Names:   append([]string(nil), names...),
+			AI:      append([]string(nil), levels...),
+		},
+		Start:  g.Clone(),
+		Moves:  make([]string, 0),
+		Winner: -1,
+		offset: len(g.History()),
+	}
+}
+
+// Finish copies the moves made since the record started, and the result
+// The game must be the one passed to New
+func (r *Record) Finish(g *game.Game) {
+	r.Moves = r.Moves[:0]
+	history := g.History()
+	for _, entry := range history[min(r.offset, len(history)):] {
+		r.Moves = append(r.Moves, game.FormatMove(entry.Move))
+	}
+
+	r.Scores = make([]int, len(g.Players))
+	for i, p := range g.Players {
+		r.Scores[i] = p.Score
+	}
+	r.Winner = g.GetWinner()
+}
+
+// Step is one position of a replayed game
+type Step struct {
+	Game   *game.Game // Position after the move (or the start position)
+	Move   *game.Move // Move that led here, nil for the start
+	Player int        // Seat that made the move
+}
+
+// Replay plays the recorded moves from the start position
+// The first step is the start position itself
+func (r *Record) Replay() ([]Step, error) {
+	if r.Start == nil {
+		return nil, fmt.Errorf("record has no start position")
+	}
+
+	g := r.Start.Clone()
+	steps := []Step{{Game: g.Clone()}}
+
+	for i, text := range r.Moves {
+		move, err := game.ParseMove(text)
+		if err != nil {
+			return steps, fmt.Errorf("move %d: %w", i+1, err)
+		}
+		player := g.CurrentPlayer
+		if err := g.ApplyMove(move); err != nil {
+			return steps, fmt.Errorf("move %d (%s): %w", i+1, text, err)
+		}
+		steps = append(steps, Step{Game: g.Clone(), Move: &move, Player: player})
+	}
+
+	return steps, nil
+}
+
+// Save writes the record to a JSON file
+func (r *Record) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Load reads a record written by Save
+func Load(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	if r.Version < 1 || r.Version > Version {
+		return nil, fmt.Errorf("unsupported record version %d (expected %d)", r.Version, Version)
+	}
+	return &r, nil
+}
@@ -0,0 +1,92 @@
+package record
+
+import (
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// playRandom plays random legal moves until the game ends or stop moves are made
+func playRandom(t *testing.T, g *game.Game, rng *rand.Rand, stop int) {
+	t.Helper()
+	for i := 0; !g.GameOver && i != stop; i++ {
+		moves := g.GetValidMoves()
+		if len(moves) == 0 {
+			t.Fatal("no legal moves in an unfinished game")
+		}
+		if err := g.ApplyMove(moves[rng.Intn(len(moves))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSaveLoadReplay(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		variant game.Variant
+		before  int  // Moves played before the record starts
+		resume  bool // Save and reload the game first, as -load does
+	}{
+		{"whole game", game.ColoredWall, 0, false},
+		{"started mid-game", game.ColoredWall, 7, false},
+		{"resumed game", game.ColoredWall, 7, true},
+		{"gray wall", game.GrayWall, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			g := game.NewGameWithSeed(3, 42, game.WithVariant(tc.variant))
+			playRandom(t, g, rng, tc.before)
+			offset := tc.before
+			if tc.resume {
+				path := filepath.Join(t.TempDir(), "save.json")
+				if err := g.SaveFile(path); err != nil {
+					t.Fatal(err)
+				}
+				resumed, err := game.LoadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				g, offset = resumed, len(resumed.History())
+			}
+
+			r := New(g, []string{"a", "b", "c"}, []string{"", "easy", "hard"})
+			playRandom(t, g, rng, -1)
+			r.Finish(g)
+			if want := len(g.History()) - offset; len(r.Moves) != want {
+				t.Errorf("recorded %d moves, want the %d made since the record started", len(r.Moves), want)
+			}
+
+			path := filepath.Join(t.TempDir(), "game.json")
+			if err := r.Save(path); err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(loaded.Header.Names, r.Header.Names) || loaded.Header.Seed != r.Header.Seed {
+				t.Errorf("header changed on load: %+v, want %+v", loaded.Header, r.Header)
+			}
+
+			steps, err := loaded.Replay()
+			if err != nil {
+				t.Fatal(err)
+			}
+			end := steps[len(steps)-1].Game
+			if !end.GameOver {
+				t.Fatal("the replay doesn't end the game")
+			}
+			for i, p := range end.Players {
+				if p.Score != r.Scores[i] || p.Score != g.Players[i].Score {
+					t.Errorf("player %d: replay scores %d, record %d, game %d", i+1, p.Score, r.Scores[i], g.Players[i].Score)
+				}
+			}
+			if end.GetWinner() != loaded.Winner {
+				t.Errorf("replay winner %d, record %d", end.GetWinner(), loaded.Winner)
+			}
+		})
+	}
+}
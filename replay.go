@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/record"
+)
+
+// runReplay implements `azul-ai replay FILE`: step through a recorded game
+func runReplay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println("Usage: azul-ai replay FILE")
+		fmt.Println("  Step through a game record written at the end of a game")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	rec, err := record.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	steps, err := rec.Replay()
+	if err != nil {
+		// Show what could be replayed before the bad move
+		fmt.Printf("Warning: %v\n", err)
+		waitForEnter(bufio.NewReader(os.Stdin))
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	names := rec.Header.Names
+	pos := 0
+	last := len(steps) - 1
+
+	for {
+		step := steps[pos]
+		fmt.Print(display.RenderGame(step.Game, names))
+		fmt.Print(renderReplayStatus(rec, steps, pos))
+
+		if pos == last && step.Game.GameOver {
+			fmt.Print(display.RenderGameOver(step.Game, names))
+		}
+
+		fmt.Print("\n  [Enter/n] next  [p] previous  [f] first  [l] last  [number] go to move  [q] quit: ")
+		input := readInput(reader)
+
+		switch input {
+		case "", "n", "next":
+			pos = min(pos+1, last)
+		case "p", "b", "prev", "back":
+			pos = max(pos-1, 0)
+		case "f", "first":
+			pos = 0
+		case "l", "last":
+			pos = last
+		case "q", "quit":
+			return
+		default:
+			num, err := strconv.Atoi(input)
+			if err != nil || num < 0 || num > last {
+				fmt.Printf("\n  %sEnter a move number from 0 to %d%s\n", display.Red, last, display.Reset)
+				waitForEnter(reader)
+				continue
+			}
+			pos = num
+		}
+	}
+}
+
+// renderReplayStatus describes the move that led to the current replay position
+func renderReplayStatus(rec *record.Record, steps []record.Step, pos int) string {
+	h := rec.Header
+	status := fmt.Sprintf("  %sReplay%s  %s, %d players, seed %d, %s\n",
+		display.Bold, display.Reset, h.Date.Format("2006-01-02 15:04"), h.Players, h.Seed, h.Variant)
+
+	step := steps[pos]
+	if step.Move == nil {
+		return status + fmt.Sprintf("  Start position (%d moves recorded)\n", len(steps)-1)
+	}
+
+	name := fmt.Sprintf("Player %d", step.Player+1)
+	if step.Player < len(h.Names) && h.Names[step.Player] != "" {
+		name = h.Names[step.Player]
+	}
+	return status + fmt.Sprintf("  Move %d/%d: %s%s%s played %s (%s)\n",
+		pos, len(steps)-1, display.Bold, name, display.Reset, game.FormatMove(*step.Move), step.Move.String())
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// roundScoring gathers each round's scoring events into a summary for the
+// end-of-round screen
+type roundScoring struct {
+	tiles  map[[2]int]game.TilePlacedOnWall // By player and row
+	order  [][2]int
+	floors []game.FloorPenalty
+	ready  *display.RoundSummary
+}
+
+// OnEvent records wall tiles and floor penalties until the round ends
+// A Gray Wall placement taken back and played again replaces the first one.
+func (r *roundScoring) OnEvent(e game.Event) {
+	switch e := e.(type) {
+	case game.TilePlacedOnWall:
+		key := [2]int{e.Player, e.Row}
+		if r.tiles == nil {
+			r.tiles = map[[2]int]game.TilePlacedOnWall{}
+		}
+		if _, ok := r.tiles[key]; !ok {
+			r.order = append(r.order, key)
+		}
+		r.tiles[key] = e
+	case game.FloorPenalty:
+		r.floors = append(r.floors, e)
+	case game.RoundEnded:
+		s := &display.RoundSummary{Round: e.Round, Floors: r.floors, Scores: e.Scores}
+		for _, key := range r.order {
+			s.Tiles = append(s.Tiles, r.tiles[key])
+		}
+		r.ready = s
+		r.reset()
+	}
+}
+
+// reset forgets the round being gathered
+func (r *roundScoring) reset() {
+	r.tiles, r.order, r.floors = nil, nil, nil
+}
+
+// take returns the summary of the round that just ended, once
+func (r *roundScoring) take() *display.RoundSummary {
+	s := r.ready
+	r.ready = nil
+	return s
+}
+
+// sync drops what undo took back: a drafting position has no tiling in
+// progress, and while tiling only the tiles still on the walls count
+func (r *roundScoring) sync(g *game.Game) {
+	r.ready = nil
+	if g.Phase == game.PhaseDrafting {
+		r.reset()
+		return
+	}
+	var order [][2]int
+	for _, key := range r.order {
+		t := r.tiles[key]
+		if g.Players[t.Player].Wall[t.Row][t.Column] {
+			order = append(order, key)
+		} else {
+			delete(r.tiles, key)
+		}
+	}
+	r.order = order
+}
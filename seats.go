@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+)
+
+// seatSpec says who plays one seat
+type seatSpec struct {
+	human  bool
+	name   string        // Human's name, "" if not given
+	level  ai.Difficulty // AI level
+	engine []string      // Command line of an external engine
+}
+
+// parseSeats parses a -seats list such as "human:Alice,hard,human:Bob,medium"
+// An external engine is given as "engine:COMMAND ARGS"
+func parseSeats(list string) ([]seatSpec, error) {
+	parts := strings.Split(list, ",")
+	if len(parts) < 2 || len(parts) > 4 {
+		return nil, fmt.Errorf("-seats needs 2-4 seats, got %d", len(parts))
+	}
+
+	seats := make([]seatSpec, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		kind, name, _ := strings.Cut(part, ":")
+		kind = strings.ToLower(kind)
+
+		switch kind {
+		case "human":
+			seats[i] = seatSpec{human: true, name: strings.TrimSpace(name)}
+			continue
+		case "engine":
+			command := strings.Fields(name)
+			if len(command) == 0 {
+				return nil, fmt.Errorf("seat %q has no engine command", part)
+			}
+			seats[i] = seatSpec{engine: command}
+			continue
+		}
+		level, ok := ai.DifficultyFromString(kind)
+		if !ok || name != "" {
+			return nil, fmt.Errorf("unknown seat %q (use human, human:NAME, engine:COMMAND, easy, medium, hard or mcts)", part)
+		}
+		seats[i] = seatSpec{level: level}
+	}
+	return seats, nil
+}
+
+// defaultSeats builds the seats chosen with -players, -human and -ai
+func defaultSeats(numPlayers, human int, level ai.Difficulty) []seatSpec {
+	seats := make([]seatSpec, numPlayers)
+	for i := range seats {
+		seats[i] = seatSpec{human: i+1 == human, level: level}
+	}
+	return seats
+}
+
+// humanCount counts the human seats
+func humanCount(seats []seatSpec) int {
+	n := 0
+	for _, s := range seats {
+		if s.human {
+			n++
+		}
+	}
+	return n
+}
+
+// humanLabel names a human seat on screen
+// A lone unnamed human is "You"; unnamed humans sharing a terminal are numbered
+func humanLabel(seats []seatSpec, i int) string {
+	switch {
+	case seats[i].name != "":
+		return seats[i].name
+	case humanCount(seats) == 1:
+		return "You"
+	default:
+		return fmt.Sprintf("Player %d", i+1)
+	}
+}
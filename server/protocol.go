@@ -0,0 +1,117 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// The protocol is newline-delimited JSON over TCP: every line is one Message.
+//
+// A client connects and sends a join. The server answers with welcome (the
+// client's seat), then sends state before every turn, moved after every move
+// and gameover at the end. Game events (game.Event) are sent as event
+// messages as they happen, so clients can animate a move's consequences;
+// they come before the moved message of the move that caused them. On its
+// turn a client sends a move in notation ("3B2", "CY-", "2B@3"); an illegal
+// move is answered with error and the client is asked again.
+
+// Message types
+const (
+	MsgJoin     = "join"     // Client: join the game as Name
+	MsgMove     = "move"     // Client: play Move
+	MsgWelcome  = "welcome"  // Server: you sit at Seat; Names lists the seats joined so far
+	MsgState    = "state"    // Server: Game before Seat's turn; Moves lists the legal moves on your turn
+	MsgMoved    = "moved"    // Server: Seat played Move
+	MsgGameOver = "gameover" // Server: final Game, Scores and Winner (-1 for a shared victory)
+	MsgEvent    = "event"    // Server: game Event happened, with its JSON Data (see game.DecodeEvent)
+	MsgInfo     = "info"     // Server: Text to show the player
+	MsgError    = "error"    // Server: Text explains what was wrong
+)
+
+// Message is one line of the protocol
+type Message struct {
+	Type   string     `json:"type"`
+	Seat   int        `json:"seat"`
+	Name   string     `json:"name,omitempty"`
+	Names  []string   `json:"names,omitempty"`
+	Move   string     `json:"move,omitempty"`
+	Moves  []string   `json:"moves,omitempty"`
+	Game   *game.Game `json:"game,omitempty"`
+	Scores []int      `json:"scores,omitempty"`
+	Winner int        `json:"winner"`
+	Text   string     `json:"text,omitempty"`
+
+	Event string          `json:"event,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// maxLine bounds one protocol line (a game state is a few kilobytes)
+const maxLine = 1 << 20
+
+// Conn is one end of a protocol connection
+// Send is safe for concurrent use; Receive must be called from one goroutine
+type Conn struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	mu      sync.Mutex
+}
+
+// NewConn wraps a network connection
+func NewConn(c net.Conn) *Conn {
+	scanner := bufio.NewScanner(c)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+	return &Conn{conn: c, scanner: scanner}
+}
+
+// Dial connects to a server
+func Dial(addr string) (*Conn, error) {
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(c), nil
+}
+
+// Send writes one message
+func (c *Conn) Send(m Message) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.conn.Write(append(data, '\n'))
+	return err
+}
+
+// Receive reads the next message
+func (c *Conn) Receive() (Message, error) {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return Message{}, err
+		}
+		return Message{}, fmt.Errorf("connection closed")
+	}
+
+	var m Message
+	if err := json.Unmarshal(c.scanner.Bytes(), &m); err != nil {
+		return Message{}, fmt.Errorf("bad message: %w", err)
+	}
+	return m, nil
+}
+
+// Close closes the connection
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// RemoteAddr returns the address of the other end
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
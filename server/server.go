@@ -0,0 +1,433 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// joinTimeout is how long a new connection has to send its join
+const joinTimeout = 30 * time.Second
+
+// Config describes a hosted game
+type Config struct {
+	Players int // Seats at the table (2-4)
+	Variant game.Variant
+	// Wait is how long to wait for clients before AIs take the empty seats
+	// (0 = until every seat is taken)
+	Wait      time.Duration
+	AI        ai.Difficulty // Level of the AIs in empty seats
+	AIOptions []ai.Option
+	Logf      func(format string, args ...any) // Progress log (nil = silent)
+}
+
+// seat is one place at the table, taken by a client or an AI
+type seat struct {
+	name string
+	conn *Conn     // nil for an AI
+	ai   ai.Player // nil for a client
+}
+
+// incoming is a message from a client, or the error that ended its connection
+type incoming struct {
+	seat int
+	conn *Conn
+	msg  Message
+	err  error
+}
+
+// Server hosts one game for remote clients
+type Server struct {
+	cfg      Config
+	ln       net.Listener
+	rng      *rand.Rand
+	joined   chan struct{}
+	incoming chan incoming
+	done     chan struct{}
+
+	mu      sync.Mutex
+	seats   []*seat
+	started bool
+	closed  bool
+}
+
+// Listen starts accepting clients on addr (e.g. ":7777")
+func Listen(addr string, cfg Config) (*Server, error) {
+	if cfg.Players < 2 || cfg.Players > 4 {
+		return nil, fmt.Errorf("a game needs 2-4 players, got %d", cfg.Players)
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		cfg:      cfg,
+		ln:       ln,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		joined:   make(chan struct{}, 1),
+		incoming: make(chan incoming, 16),
+		done:     make(chan struct{}),
+		seats:    make([]*seat, cfg.Players),
+	}
+	go s.acceptLoop()
+	return s, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() net.Addr {
+	return s.ln.Addr()
+}
+
+// Close stops the server and disconnects every client
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+
+	for _, st := range s.seats {
+		if st != nil && st.conn != nil {
+			st.conn.Close()
+		}
+	}
+	return s.ln.Close()
+}
+
+// Run waits for clients, plays the game and returns it once it's over
+// Names holds the name of each seat. A client that disconnects is replaced by an AI.
+func (s *Server) Run(ctx context.Context) (g *game.Game, names []string, err error) {
+	if err := s.lobby(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	s.started = true
+	for i, st := range s.seats {
+		if st == nil {
+			p := s.newAI(i)
+			s.seats[i] = &seat{name: p.Name(), ai: p}
+		}
+	}
+	s.mu.Unlock()
+
+	names = s.names()
+	s.logf("Game starting: %v", names)
+	s.broadcast(Message{Type: MsgInfo, Names: names, Text: "The game is starting"})
+
+	g = game.NewGame(s.cfg.Players, game.WithVariant(s.cfg.Variant), game.WithUndo(false), game.WithSubscriber(game.SubscriberFunc(s.sendEvent)))
+	for !g.GameOver {
+		moves := g.GetValidMoves()
+		if len(moves) == 0 {
+			return g, names, fmt.Errorf("no legal moves in round %d", g.Round)
+		}
+
+		player := g.CurrentPlayer
+		s.sendState(g, player, moves)
+		move, err := s.playTurn(ctx, g, player, moves)
+		if err != nil {
+			return g, s.names(), err
+		}
+		s.broadcast(Message{Type: MsgMoved, Seat: player, Move: game.FormatMove(move)})
+	}
+
+	names = s.names()
+	scores := make([]int, len(g.Players))
+	for i, p := range g.Players {
+		scores[i] = p.Score
+	}
+	s.broadcast(Message{Type: MsgGameOver, Names: names, Game: g, Scores: scores, Winner: g.GetWinner()})
+	s.logf("Game over: scores %v", scores)
+
+	return g, names, nil
+}
+
+// lobby waits until every seat is taken or the wait runs out
+func (s *Server) lobby(ctx context.Context) error {
+	var timeout <-chan time.Time
+	if s.cfg.Wait > 0 {
+		timer := time.NewTimer(s.cfg.Wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for !s.full() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return nil
+		case <-s.joined:
+		case in := <-s.incoming:
+			if in.err != nil && s.isCurrent(in) {
+				s.leave(in.seat)
+			}
+		}
+	}
+	return nil
+}
+
+// playTurn gets a legal move from the seat to play and applies it
+// Clients are asked again after an illegal move
+func (s *Server) playTurn(ctx context.Context, g *game.Game, player int, moves []game.Move) (game.Move, error) {
+	for {
+		s.mu.Lock()
+		st := s.seats[player]
+		s.mu.Unlock()
+
+		if st.ai != nil {
+			move := ai.ChooseMove(ctx, st.ai, g, moves)
+			if err := g.ApplyMove(move); err != nil {
+				return move, fmt.Errorf("%s played %s: %w", st.name, game.FormatMove(move), err)
+			}
+			return move, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return game.Move{}, ctx.Err()
+		case in := <-s.incoming:
+			if !s.isCurrent(in) {
+				continue // From a seat that has since been handed to an AI
+			}
+			if in.err != nil {
+				s.leave(in.seat)
+				continue
+			}
+			if in.msg.Type != MsgMove {
+				continue
+			}
+			if in.seat != player {
+				in.conn.Send(Message{Type: MsgError, Seat: in.seat, Text: "It's not your turn"})
+				continue
+			}
+
+			move, err := game.ParseMove(in.msg.Move)
+			if err == nil {
+				err = g.ApplyMove(move)
+			}
+			if err != nil {
+				in.conn.Send(Message{Type: MsgError, Seat: player, Text: fmt.Sprintf("Illegal move %q: %v", in.msg.Move, err)})
+				continue
+			}
+			return move, nil
+		}
+	}
+}
+
+// sendState tells every client about the position before player's turn
+// Clients see a determinized copy: the bag is reshuffled and its seed replaced,
+// so the real draw order stays hidden
+func (s *Server) sendState(g *game.Game, player int, moves []game.Move) {
+	public := g.Determinize(s.rng)
+	notation := make([]string, len(moves))
+	for i, move := range moves {
+		notation[i] = game.FormatMove(move)
+	}
+	names := s.names()
+
+	for i, conn := range s.conns() {
+		if conn == nil {
+			continue
+		}
+		m := Message{Type: MsgState, Seat: player, Names: names, Game: public}
+		if i == player {
+			m.Moves = notation
+		}
+		conn.Send(m)
+	}
+}
+
+// acceptLoop accepts connections until the listener closes
+func (s *Server) acceptLoop() {
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.join(NewConn(c))
+	}
+}
+
+// join seats a new connection once it has sent its join message
+func (s *Server) join(conn *Conn) {
+	conn.conn.SetReadDeadline(time.Now().Add(joinTimeout))
+	m, err := conn.Receive()
+	if err != nil || m.Type != MsgJoin {
+		conn.Send(Message{Type: MsgError, Text: "Expected a join message"})
+		conn.Close()
+		return
+	}
+	conn.conn.SetReadDeadline(time.Time{})
+
+	name := m.Name
+	if name == "" {
+		name = "Player"
+	}
+
+	s.mu.Lock()
+	idx := -1
+	if !s.started && !s.closed {
+		for i, st := range s.seats {
+			if st == nil {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		started := s.started
+		s.mu.Unlock()
+		text := "The game is full"
+		if started {
+			text = "The game has already started"
+		}
+		conn.Send(Message{Type: MsgError, Text: text})
+		conn.Close()
+		return
+	}
+	s.seats[idx] = &seat{name: name, conn: conn}
+	s.mu.Unlock()
+
+	names := s.names()
+	s.logf("%s joined from %s (seat %d)", name, conn.RemoteAddr(), idx+1)
+	conn.Send(Message{Type: MsgWelcome, Seat: idx, Names: names})
+	s.broadcast(Message{Type: MsgInfo, Names: names, Text: fmt.Sprintf("%s joined (%d/%d)", name, s.taken(), s.cfg.Players)})
+
+	select {
+	case s.joined <- struct{}{}:
+	default:
+	}
+
+	go s.readLoop(idx, conn)
+}
+
+// readLoop forwards a client's messages to the game until the connection ends
+func (s *Server) readLoop(idx int, conn *Conn) {
+	for {
+		m, err := conn.Receive()
+		select {
+		case s.incoming <- incoming{seat: idx, conn: conn, msg: m, err: err}:
+		case <-s.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// leave frees a disconnected client's seat, or hands it to an AI once the game has started
+func (s *Server) leave(idx int) {
+	s.mu.Lock()
+	st := s.seats[idx]
+	st.conn.Close()
+	name := st.name
+	started := s.started
+	if started {
+		p := s.newAI(idx)
+		s.seats[idx] = &seat{name: name + " (AI)", ai: p}
+	} else {
+		s.seats[idx] = nil
+	}
+	s.mu.Unlock()
+
+	s.logf("%s disconnected", name)
+	text := fmt.Sprintf("%s left", name)
+	if started {
+		text += ", an AI takes over"
+	}
+	s.broadcast(Message{Type: MsgInfo, Names: s.names(), Text: text})
+}
+
+// newAI creates the AI for an empty seat
+func (s *Server) newAI(idx int) ai.Player {
+	return ai.NewAIPlayer(s.cfg.AI, idx, s.cfg.AIOptions...)
+}
+
+// isCurrent reports whether a message comes from the client that holds its seat
+func (s *Server) isCurrent(in incoming) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.seats[in.seat]
+	return st != nil && st.conn == in.conn
+}
+
+// sendEvent broadcasts a game event
+func (s *Server) sendEvent(e game.Event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		s.logf("Encoding %s: %v", game.EventName(e), err)
+		return
+	}
+	s.broadcast(Message{Type: MsgEvent, Event: game.EventName(e), Data: data})
+}
+
+// broadcast sends a message to every client
+func (s *Server) broadcast(m Message) {
+	for _, conn := range s.conns() {
+		if conn != nil {
+			conn.Send(m)
+		}
+	}
+}
+
+// conns returns each seat's connection (nil for AIs and empty seats)
+func (s *Server) conns() []*Conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conns := make([]*Conn, len(s.seats))
+	for i, st := range s.seats {
+		if st != nil {
+			conns[i] = st.conn
+		}
+	}
+	return conns
+}
+
+// names returns the name of each seat ("" while empty)
+func (s *Server) names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, len(s.seats))
+	for i, st := range s.seats {
+		if st != nil {
+			names[i] = st.name
+		}
+	}
+	return names
+}
+
+// taken counts the occupied seats
+func (s *Server) taken() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, st := range s.seats {
+		if st != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// full reports whether every seat is taken
+func (s *Server) full() bool {
+	return s.taken() == s.cfg.Players
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.cfg.Logf != nil {
+		s.cfg.Logf(format, args...)
+	}
+}
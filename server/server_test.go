@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// playClient joins s and plays the first legal move on every turn until the
+// game is over, returning the bag seed of every state it was sent
+func playClient(t *testing.T, s *Server, name string) <-chan []int64 {
+	t.Helper()
+	conn, err := Dial(s.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Send(Message{Type: MsgJoin, Name: name}); err != nil {
+		t.Fatal(err)
+	}
+
+	seeds := make(chan []int64, 1)
+	go func() {
+		defer conn.Close()
+		var got []int64
+		seat := -1
+		for {
+			m, err := conn.Receive()
+			if err != nil {
+				seeds <- got
+				return
+			}
+			switch m.Type {
+			case MsgWelcome:
+				seat = m.Seat
+			case MsgState:
+				got = append(got, m.Game.Bag.Seed())
+				if m.Seat == seat && len(m.Moves) > 0 {
+					conn.Send(Message{Type: MsgMove, Move: m.Moves[0]})
+				}
+			case MsgGameOver:
+				seeds <- got
+				return
+			}
+		}
+	}()
+	return seeds
+}
+
+func TestStateHidesBagSeed(t *testing.T) {
+	s, err := Listen("127.0.0.1:0", Config{Players: 2, Variant: game.ColoredWall})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	clients := []<-chan []int64{playClient(t, s, "Ann"), playClient(t, s, "Bob")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	g, _, err := s.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range clients {
+		seeds := <-c
+		if len(seeds) == 0 {
+			t.Errorf("client %d got no state messages", i+1)
+		}
+		for _, seed := range seeds {
+			if seed == g.Bag.Seed() {
+				t.Errorf("client %d was sent the real bag seed", i+1)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/ratings"
+	"github.com/eddiefleurent/azul-ai/tournament"
+)
+
+// runTournament implements `azul-ai tournament`: headless AI-vs-AI games with statistics
+func runTournament(args []string) {
+	fs := flag.NewFlagSet("tournament", flag.ExitOnError)
+	games := fs.Int("games", 100, "Number of games to play")
+	lineup := fs.String("ai", "hard,medium", "Comma-separated AI for each seat (2-4 of easy, medium, hard, mcts, LEVEL:WEIGHTS, engine:COMMAND)")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed of the first game (game i uses seed+i)")
+	workers := fs.Int("workers", 0, "Games played in parallel (0 = one per CPU)")
+	variantName := fs.String("variant", "standard", "Rules variant: standard, gray")
+	thinkTime := fs.Duration("think", 0, "Hard AI and engine time budget per move (0 = fixed depth / engine default)")
+	searchName := fs.String("search", "auto", "Hard AI search: auto, paranoid, maxn")
+	ttSize := fs.Int("tt", ai.DefaultTTSize, "Hard AI transposition table entries (0 = off)")
+	mctsIterations := fs.Int("mcts-iterations", ai.DefaultMCTSConfig().Iterations, "MCTS playouts per move (0 = time limit only)")
+	mctsTime := fs.Duration("mcts-time", ai.DefaultMCTSConfig().TimeLimit, "MCTS thinking time per move (0 = iterations only)")
+	ratingsPath := fs.String("ratings", ratings.DefaultPath, "Ratings file to record the games in (empty to disable)")
+	fs.Usage = func() {
+		fmt.Println("Usage: azul-ai tournament [options]")
+		fmt.Println("  Play AI-vs-AI games without rendering and report how each AI did")
+		fmt.Println()
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	variant, ok := game.VariantFromString(strings.ToLower(*variantName))
+	if !ok {
+		fmt.Printf("Unknown variant %q (use standard or gray)\n", *variantName)
+		os.Exit(1)
+	}
+	searchMode, ok := ai.SearchModeFromString(strings.ToLower(*searchName))
+	if !ok {
+		fmt.Printf("Unknown search mode %q (use auto, paranoid or maxn)\n", *searchName)
+		os.Exit(1)
+	}
+	mctsConfig := ai.DefaultMCTSConfig()
+	mctsConfig.Iterations = *mctsIterations
+	mctsConfig.TimeLimit = *mctsTime
+
+	var entrants []tournament.Entrant
+	seen := make(map[string]int)
+	for _, level := range strings.Split(*lineup, ",") {
+		level = strings.TrimSpace(level)
+		if command, ok := strings.CutPrefix(level, "engine:"); ok {
+			entrant, err := engineEntrant(strings.Fields(command), *thinkTime)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			entrants = append(entrants, entrant)
+			continue
+		}
+
+		// LEVEL:WEIGHTS plays with a weight preset or weights file
+		level, weightsSpec, _ := strings.Cut(level, ":")
+		level = strings.ToLower(level)
+		difficulty, ok := ai.DifficultyFromString(level)
+		if !ok {
+			fmt.Printf("Unknown AI level %q (use easy, medium, hard or mcts)\n", level)
+			os.Exit(1)
+		}
+		opts := []ai.Option{ai.WithMCTS(mctsConfig), ai.WithSearchMode(searchMode),
+			ai.WithThinkTime(*thinkTime), ai.WithTranspositionTable(*ttSize)}
+		if weightsSpec != "" {
+			weights, err := loadWeights(weightsSpec)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts = append(opts, ai.WithWeights(weights))
+			level += ":" + weights.Name
+		}
+
+		// Tell apart several AIs of the same level
+		seen[level]++
+		name := level
+		if seen[level] > 1 {
+			name = fmt.Sprintf("%s#%d", level, seen[level])
+		}
+		entrants = append(entrants, tournament.AIEntrant(name, difficulty, opts...))
+	}
+
+	cfg := tournament.Config{
+		Entrants: entrants,
+		Games:    *games,
+		Seed:     *seed,
+		Workers:  *workers,
+		Variant:  variant,
+	}
+
+	// Ctrl-C stops the tournament and reports the games finished so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Tournament: %d games, %s, %s rules, seed %d\n", cfg.Games, *lineup, variant, cfg.Seed)
+	start := time.Now()
+	played := 0
+	results, err := tournament.Run(ctx, cfg, func(r tournament.GameResult) {
+		played++
+		if r.Err != nil {
+			fmt.Printf("\rGame %d (seed %d) failed: %v\n", r.Game+1, r.Seed, r.Err)
+		}
+		fmt.Printf("\rPlayed %d/%d games", played, cfg.Games)
+	})
+	fmt.Println()
+	if err != nil && len(results) == 0 {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Printf("Stopped early: %v\n", err)
+	}
+
+	fmt.Printf("Finished %d games in %s\n\n", len(results), time.Since(start).Round(time.Millisecond))
+	fmt.Print(renderStandings(tournament.Summarize(entrants, results)))
+
+	if *ratingsPath != "" {
+		if err := rateTournament(*ratingsPath, entrants, results); err != nil {
+			fmt.Printf("Error updating ratings: %v\n", err)
+		} else {
+			fmt.Printf("\nRatings updated in %s (see them with: azul-ai ratings)\n", *ratingsPath)
+		}
+	}
+}
+
+// rateTournament records the finished tournament games in the ratings file, in game order
+func rateTournament(path string, entrants []tournament.Entrant, results []tournament.GameResult) error {
+	store, err := ratings.Load(path)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		ids := make([]string, len(r.Seats))
+		for seat, entrant := range r.Seats {
+			ids[seat] = entrants[entrant].RatingID()
+		}
+		store.RecordGame(ids, r.Scores, r.Rows, r.Winner)
+	}
+
+	return store.Save(path)
+}
+
+// renderStandings formats tournament standings as a table
+func renderStandings(standings []tournament.Standing) string {
+	var sb strings.Builder
+
+	width := 10
+	for _, s := range standings {
+		width = max(width, len(s.Name))
+	}
+
+	sb.WriteString(display.Bold)
+	sb.WriteString(fmt.Sprintf("%-*s %5s %6s  %-22s %-15s %6s  %-22s %9s",
+		width, "AI", "Games", "Wins", "Win rate (95% CI)", "Score (95% CI)", "StdDev", "Elo (95% CI)", "Time/move"))
+	sb.WriteString(display.Reset + "\n")
+
+	for _, s := range standings {
+		sb.WriteString(fmt.Sprintf("%-*s %5d %6.1f  %-22s %-15s %6.1f  %-22s %9s\n",
+			width, s.Name, s.Games, s.Wins,
+			fmt.Sprintf("%5.1f%% (%.1f-%.1f)", s.WinRate*100, s.WinLow*100, s.WinHigh*100),
+			fmt.Sprintf("%5.1f ± %.1f", s.MeanScore, s.ScoreCI),
+			s.StdDev,
+			fmt.Sprintf("%+5.0f (%+.0f..%+.0f)", s.Elo, s.EloLow, s.EloHigh),
+			s.MoveTime.Round(time.Microsecond)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,250 @@
+package tournament
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// z95 is the normal quantile for 95% confidence intervals
+const z95 = 1.959964
+
+// bootstrapSamples is how many resampled tournaments estimate the Elo intervals
+const bootstrapSamples = 1000
+
+// Standing summarizes how one entrant did
+type Standing struct {
+	Name    string
+	Games   int
+	Wins    float64 // Shared victories count as a fraction of a win
+	WinRate float64
+	WinLow  float64 // 95% confidence interval of the win rate
+	WinHigh float64
+
+	MeanScore float64
+	StdDev    float64
+	ScoreCI   float64 // Half-width of the 95% confidence interval of the mean score
+
+	Elo     float64 // Relative to the field average (0)
+	EloLow  float64 // 95% confidence interval of the Elo estimate
+	EloHigh float64
+
+	MoveTime time.Duration // Average thinking time per move
+}
+
+// Summarize computes each entrant's standing from finished games
+// Games that ended in an error are left out
+func Summarize(entrants []Entrant, results []GameResult) []Standing {
+	n := len(entrants)
+	standings := make([]Standing, n)
+	scores := make([][]float64, n)
+	moves := make([]int, n)
+	thinking := make([]time.Duration, n)
+
+	var games []GameResult
+	for _, r := range results {
+		if r.Err == nil {
+			games = append(games, r)
+		}
+	}
+
+	for _, r := range games {
+		shares := winShares(r)
+		for seat, entrant := range r.Seats {
+			standings[entrant].Games++
+			standings[entrant].Wins += shares[seat]
+			scores[entrant] = append(scores[entrant], float64(r.Scores[seat]))
+			thinking[entrant] += r.Time[seat]
+			moves[entrant] += r.Turns[seat]
+		}
+	}
+
+	elo := fitElo(n, games)
+	eloLow, eloHigh := bootstrapElo(n, games)
+
+	for i := range standings {
+		s := &standings[i]
+		s.Name = entrants[i].Name
+		if s.Games > 0 {
+			s.WinRate = s.Wins / float64(s.Games)
+			s.WinLow, s.WinHigh = wilson(s.Wins, s.Games)
+			s.MeanScore, s.StdDev = meanStdDev(scores[i])
+			s.ScoreCI = z95 * s.StdDev / math.Sqrt(float64(s.Games))
+		}
+		if moves[i] > 0 {
+			s.MoveTime = thinking[i] / time.Duration(moves[i])
+		}
+		s.Elo, s.EloLow, s.EloHigh = elo[i], eloLow[i], eloHigh[i]
+	}
+
+	return standings
+}
+
+// winShares splits one win between the seats that share the victory
+// (game.Leaders); a seat that lost on the tiebreak gets nothing
+func winShares(r GameResult) []float64 {
+	shares := make([]float64, len(r.Scores))
+	if r.Winner >= 0 {
+		shares[r.Winner] = 1
+		return shares
+	}
+
+	tied := game.Leaders(r.Scores, r.Rows)
+	for _, seat := range tied {
+		shares[seat] = 1 / float64(len(tied))
+	}
+	return shares
+}
+
+// pairOutcome scores seat a against seat b: 1 for a win, 0.5 for a draw, 0 for a loss
+// The game's winner beats everyone; everyone else is ranked by score, then
+// by completed rows
+func pairOutcome(r GameResult, a, b int) float64 {
+	switch {
+	case r.Winner == a:
+		return 1
+	case r.Winner == b:
+		return 0
+	}
+	switch c := game.CompareResults(r.Scores, r.Rows, a, b); {
+	case c > 0:
+		return 1
+	case c < 0:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// fitElo estimates Elo ratings from the pairwise results of every game
+//
+// Each game counts as a head-to-head result between every pair of seats, and
+// the ratings are the Bradley-Terry maximum likelihood fit. Every pair of
+// entrants also starts with one virtual draw, which keeps ratings finite
+// when an entrant wins or loses everything. Ratings average to 0.
+func fitElo(n int, games []GameResult) []float64 {
+	wins := make([]float64, n)    // Points scored by each entrant
+	pairs := make([][]float64, n) // Games between each pair of entrants
+	for i := range pairs {
+		pairs[i] = make([]float64, n)
+		for j := range pairs[i] {
+			if i != j {
+				pairs[i][j] = 1
+			}
+		}
+		wins[i] = 0.5 * float64(n-1)
+	}
+
+	for _, r := range games {
+		for a := range r.Seats {
+			for b := a + 1; b < len(r.Seats); b++ {
+				ea, eb := r.Seats[a], r.Seats[b]
+				outcome := pairOutcome(r, a, b)
+				wins[ea] += outcome
+				wins[eb] += 1 - outcome
+				pairs[ea][eb]++
+				pairs[eb][ea]++
+			}
+		}
+	}
+
+	// Minorization-maximization iterations on the strengths
+	strength := make([]float64, n)
+	for i := range strength {
+		strength[i] = 1
+	}
+	for iter := 0; iter < 200; iter++ {
+		next := make([]float64, n)
+		logSum := 0.0
+		for i := range next {
+			denom := 0.0
+			for j := range next {
+				if i != j {
+					denom += pairs[i][j] / (strength[i] + strength[j])
+				}
+			}
+			next[i] = wins[i] / denom
+			logSum += math.Log(next[i])
+		}
+
+		// Normalize to a geometric mean of 1 (average rating 0)
+		scale := math.Exp(logSum / float64(n))
+		change := 0.0
+		for i := range next {
+			next[i] /= scale
+			change = max(change, math.Abs(next[i]-strength[i]))
+		}
+		strength = next
+		if change < 1e-9 {
+			break
+		}
+	}
+
+	elo := make([]float64, n)
+	for i, s := range strength {
+		elo[i] = 400 * math.Log10(s)
+	}
+	return elo
+}
+
+// bootstrapElo estimates 95% confidence intervals of the Elo ratings by
+// refitting them on tournaments resampled from the games played
+func bootstrapElo(n int, games []GameResult) (low, high []float64) {
+	low = make([]float64, n)
+	high = make([]float64, n)
+	if len(games) == 0 {
+		return low, high
+	}
+
+	rng := rand.New(rand.NewSource(1)) // Fixed seed: the same games give the same report
+	samples := make([][]float64, n)
+	resample := make([]GameResult, len(games))
+	for b := 0; b < bootstrapSamples; b++ {
+		for i := range resample {
+			resample[i] = games[rng.Intn(len(games))]
+		}
+		for i, elo := range fitElo(n, resample) {
+			samples[i] = append(samples[i], elo)
+		}
+	}
+
+	for i := range samples {
+		sort.Float64s(samples[i])
+		low[i] = samples[i][int(0.025*float64(bootstrapSamples))]
+		high[i] = samples[i][int(0.975*float64(bootstrapSamples))-1]
+	}
+	return low, high
+}
+
+// wilson returns the 95% Wilson score interval for a win rate
+func wilson(wins float64, games int) (low, high float64) {
+	n := float64(games)
+	p := wins / n
+	z2 := z95 * z95
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	spread := z95 * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+	return max(0, center-spread), min(1, center+spread)
+}
+
+// meanStdDev returns the mean and sample standard deviation of values
+func meanStdDev(values []float64) (mean, stddev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values)-1))
+}
@@ -0,0 +1,204 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/game"
+)
+
+// moveLimit ends a game that somehow never finishes
+const moveLimit = 1000
+
+// Entrant is one AI taking part in a tournament
+type Entrant struct {
+	Name string
+	ID   string // Identity the entrant is rated under (the name if empty)
+	// NewPlayer creates the AI for one game, seated at seat and seeded with seed
+	// Players that are io.Closers are closed when their game ends
+	NewPlayer func(seat int, seed int64) (ai.Player, error)
+}
+
+// AIEntrant returns an entrant that plays as an AIPlayer of the given difficulty
+func AIEntrant(name string, difficulty ai.Difficulty, opts ...ai.Option) Entrant {
+	id := ai.NewAIPlayer(difficulty, 0, append(slices.Clone(opts), ai.WithTranspositionTable(0))...).Identity()
+	return Entrant{
+		Name: name,
+		ID:   id,
+		NewPlayer: func(seat int, seed int64) (ai.Player, error) {
+			seatOpts := append([]ai.Option{ai.WithSeed(seed)}, opts...)
+			return ai.NewAIPlayer(difficulty, seat, seatOpts...), nil
+		},
+	}
+}
+
+// Config describes a tournament
+type Config struct {
+	Entrants []Entrant // One per seat (2-4); seating rotates every game
+	Games    int
+	Seed     int64 // Game i is dealt with Seed+i
+	Workers  int   // Games played at once (0 = one per CPU)
+	Variant  game.Variant
+}
+
+// GameResult is the outcome of one tournament game
+type GameResult struct {
+	Game   int             // Index of the game in the tournament
+	Seed   int64           // Seed the game was dealt with
+	Seats  []int           // Entrant playing each seat
+	Scores []int           // Final score of each seat
+	Rows   []int           // Completed wall rows of each seat, the tiebreak
+	Winner int             // Winning seat, -1 for a shared victory
+	Moves  int             // Moves played
+	Turns  []int           // Moves made by each seat
+	Time   []time.Duration // Thinking time used by each seat
+	Err    error           // Why the game couldn't be finished, if it wasn't
+}
+
+// Run plays the tournament, calling progress (if not nil) as each game ends
+// Games are played in parallel without undo or rendering. If ctx is cancelled,
+// Run stops starting games and returns the results of the games already
+// finished along with ctx's error.
+func Run(ctx context.Context, cfg Config, progress func(GameResult)) ([]GameResult, error) {
+	n := len(cfg.Entrants)
+	if n < 2 || n > 4 {
+		return nil, fmt.Errorf("tournament needs 2-4 entrants, got %d", n)
+	}
+	if cfg.Games < 1 {
+		return nil, fmt.Errorf("tournament needs at least one game")
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, cfg.Games)
+
+	jobs := make(chan int)
+	results := make([]GameResult, cfg.Games)
+	done := make([]bool, cfg.Games)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := playGame(ctx, cfg, i)
+				if ctx.Err() != nil {
+					return // Cut short: don't count it
+				}
+
+				mu.Lock()
+				results[i] = result
+				done[i] = true
+				if progress != nil {
+					progress(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < cfg.Games; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	finished := make([]GameResult, 0, cfg.Games)
+	for i, result := range results {
+		if done[i] {
+			finished = append(finished, result)
+		}
+	}
+	return finished, ctx.Err()
+}
+
+// RatingID returns the identity the entrant is rated under
+func (e Entrant) RatingID() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Name
+}
+
+// playGame plays game i of the tournament
+// Seats rotate so every entrant plays every seat equally often
+func playGame(ctx context.Context, cfg Config, i int) GameResult {
+	n := len(cfg.Entrants)
+	seed := cfg.Seed + int64(i)
+	result := GameResult{
+		Game:   i,
+		Seed:   seed,
+		Seats:  make([]int, n),
+		Scores: make([]int, n),
+		Rows:   make([]int, n),
+		Winner: -1,
+		Turns:  make([]int, n),
+		Time:   make([]time.Duration, n),
+	}
+
+	players := make([]ai.Player, n)
+	for seat := range players {
+		entrant := (seat + i) % n
+		result.Seats[seat] = entrant
+		p, err := cfg.Entrants[entrant].NewPlayer(seat, seed*int64(n)+int64(seat))
+		if err != nil {
+			result.Err = fmt.Errorf("starting %s: %w", cfg.Entrants[entrant].Name, err)
+			return result
+		}
+		if c, ok := p.(io.Closer); ok {
+			defer c.Close()
+		}
+		players[seat] = p
+	}
+
+	g := game.NewGameWithSeed(n, seed, game.WithVariant(cfg.Variant), game.WithUndo(false))
+	for !g.GameOver && result.Moves < moveLimit {
+		if ctx.Err() != nil {
+			result.Err = ctx.Err()
+			return result
+		}
+
+		moves := g.GetValidMoves()
+		if len(moves) == 0 {
+			result.Err = fmt.Errorf("no legal moves in round %d", g.Round)
+			return result
+		}
+
+		seat := g.CurrentPlayer
+		start := time.Now()
+		move := ai.ChooseMove(ctx, players[seat], g, moves)
+		result.Time[seat] += time.Since(start)
+		result.Turns[seat]++
+
+		if err := g.ApplyMove(move); err != nil {
+			result.Err = fmt.Errorf("%s played %s: %w", cfg.Entrants[result.Seats[seat]].Name, game.FormatMove(move), err)
+			return result
+		}
+		result.Moves++
+	}
+	if !g.GameOver {
+		result.Err = fmt.Errorf("no result after %d moves", moveLimit)
+		return result
+	}
+
+	for seat, p := range g.Players {
+		result.Scores[seat] = p.Score
+		result.Rows[seat] = p.CompletedRows()
+	}
+	result.Winner = g.GetWinner()
+	return result
+}
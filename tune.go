@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/display"
+	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/tune"
+)
+
+// runTune implements `azul-ai tune`: optimize the evaluation weights by self-play
+func runTune(args []string) {
+	fs := flag.NewFlagSet("tune", flag.ExitOnError)
+	aiDifficulty := fs.String("ai", "medium", "AI level both sides play at: medium, hard")
+	startSpec := fs.String("start", "default", "Weights to start from: a preset name or a JSON weights file")
+	baselineSpec := fs.String("baseline", "default", "Weights of the opponents: a preset name or a JSON weights file")
+	numPlayers := fs.Int("players", 2, "Seats per game (2-4): the tuned AI and the rest baselines")
+	variantName := fs.String("variant", "standard", "Rules variant: standard, gray")
+	iterations := fs.Int("iterations", 100, "SPSA iterations")
+	games := fs.Int("games", 40, "Games per side of each iteration")
+	stepSize := fs.Float64("step", 0.2, "SPSA step size (a)")
+	perturb := fs.Float64("perturb", 0.1, "SPSA perturbation, relative to each weight (c)")
+	evalEvery := fs.Int("eval-every", 10, "Iterations between evaluations of the current weights")
+	evalGames := fs.Int("eval-games", 200, "Games per evaluation")
+	seed := fs.Int64("seed", 1, "Seed of the games and perturbations")
+	workers := fs.Int("workers", 0, "Games played in parallel (0 = one per CPU)")
+	ttSize := fs.Int("tt", ai.DefaultTTSize, "Hard AI transposition table entries (0 = off)")
+	checkpointPath := fs.String("checkpoint", "tune-checkpoint.json", "Checkpoint file, resumed from if it exists")
+	outPath := fs.String("out", "tuned-weights.json", "File to write the best weights to")
+	fs.Usage = func() {
+		fmt.Println("Usage: azul-ai tune [options]")
+		fmt.Println("  Tune the AI's evaluation weights with SPSA over seeded games against a baseline")
+		fmt.Println()
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	// Easy ignores the weights and MCTS needs too long per game to tune by self-play
+	difficulty, ok := ai.DifficultyFromString(strings.ToLower(*aiDifficulty))
+	if !ok || (difficulty != ai.Medium && difficulty != ai.Hard) {
+		fmt.Printf("Can't tune at AI level %q (use medium or hard)\n", *aiDifficulty)
+		os.Exit(1)
+	}
+	variant, ok := game.VariantFromString(strings.ToLower(*variantName))
+	if !ok {
+		fmt.Printf("Unknown variant %q (use standard or gray)\n", *variantName)
+		os.Exit(1)
+	}
+	if *numPlayers < 2 || *numPlayers > 4 {
+		fmt.Println("Number of players must be 2-4")
+		os.Exit(1)
+	}
+	start, err := loadWeights(*startSpec)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	baseline, err := loadWeights(*baselineSpec)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := tune.Config{
+		Start:      start,
+		Baseline:   baseline,
+		Difficulty: difficulty,
+		TTSize:     *ttSize,
+		Players:    *numPlayers,
+		Variant:    variant,
+		Iterations: *iterations,
+		Games:      *games,
+		StepSize:   *stepSize,
+		Perturb:    *perturb,
+		EvalEvery:  *evalEvery,
+		EvalGames:  *evalGames,
+		Seed:       *seed,
+		Workers:    *workers,
+	}
+
+	resume, err := tune.LoadCheckpoint(*checkpointPath, cfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Ctrl-C stops tuning; the checkpoint and the best weights so far are kept
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Tuning %d weights: %s vs %s at %s, %d iterations of 2×%d games, seed %d\n",
+		len(tune.ParamNames()), start.Name, baseline.Name, difficulty, cfg.Iterations, cfg.Games, cfg.Seed)
+	if resume != nil {
+		fmt.Printf("Resuming from %s at iteration %d (best %.3f)\n", *checkpointPath, resume.Iteration, resume.BestScore)
+	} else {
+		fmt.Printf("Scoring the starting weights over %d games...\n", cfg.EvalGames)
+	}
+
+	began := time.Now()
+	cp, err := tune.Run(ctx, cfg, resume, *checkpointPath, func(s tune.Step) {
+		fmt.Printf("Iteration %d/%d: plus %.3f, minus %.3f", s.Iteration, cfg.Iterations, s.Plus, s.Minus)
+		if s.Evaluated {
+			fmt.Printf("  %seval %.3f%s", display.Bold, s.Eval, display.Reset)
+		}
+		fmt.Println()
+	})
+	if cp == nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Printf("Stopped early: %v (resume with the same options)\n", err)
+	}
+	fmt.Printf("Tuned for %s\n", time.Since(began).Round(time.Second))
+
+	best := cp.Best
+	best.Name = strings.TrimSuffix(filepath.Base(*outPath), filepath.Ext(*outPath))
+	if err := best.Save(*outPath); err != nil {
+		fmt.Printf("Error saving weights: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Best weights (score %.3f against %s) written to %s\n", cp.BestScore, baseline.Name, *outPath)
+	fmt.Printf("Play with them: azul-ai -ai %s -weights %s\n", difficulty, *outPath)
+}
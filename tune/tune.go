@@ -0,0 +1,320 @@
+package tune
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/eddiefleurent/azul-ai/ai"
+	"github.com/eddiefleurent/azul-ai/game"
+	"github.com/eddiefleurent/azul-ai/tournament"
+)
+
+// CheckpointVersion is the version written into checkpoint files
+const CheckpointVersion = 1
+
+// evalSeedOffset keeps the seeds of evaluations apart from those of SPSA steps
+const evalSeedOffset = 1 << 40
+
+// Config describes a tuning run
+type Config struct {
+	Start      ai.EvalWeights // Weights to start from
+	Baseline   ai.EvalWeights // Weights of the opponents
+	Difficulty ai.Difficulty  // Level both sides play at
+	TTSize     int            // Hard AI transposition table entries
+	Players    int            // Seats per game: the candidate and Players-1 baselines
+	Variant    game.Variant
+
+	Iterations int
+	Games      int     // Games per side of each SPSA step
+	StepSize   float64 // SPSA a: size of the updates
+	Perturb    float64 // SPSA c: size of the perturbations, relative to each weight
+	Stability  float64 // SPSA A: damps the first updates (0 = a tenth of Iterations)
+
+	EvalEvery int // Iterations between evaluations of the current weights
+	EvalGames int // Games per evaluation
+
+	Seed    int64
+	Workers int // Games played at once (0 = one per CPU)
+}
+
+// Step is the outcome of one SPSA iteration
+type Step struct {
+	Iteration int     `json:"iteration"`
+	Plus      float64 `json:"plus"`  // Score of the weights perturbed up
+	Minus     float64 `json:"minus"` // Score of the weights perturbed down
+	Eval      float64 `json:"eval"`  // Score of the updated weights, if evaluated this iteration
+	Evaluated bool    `json:"evaluated"`
+}
+
+// Checkpoint is the saved progress of a tuning run
+type Checkpoint struct {
+	Version   int            `json:"version"`
+	Seed      int64          `json:"seed,string"` // Config of the run, checked on resume
+	Start     ai.EvalWeights `json:"start"`
+	Baseline  ai.EvalWeights `json:"baseline"`
+	Iteration int            `json:"iteration"` // Iterations completed
+	Scale     []float64      `json:"scale"`     // Unit each weight is tuned in, fixed at the start
+	Current   ai.EvalWeights `json:"current"`
+	Best      ai.EvalWeights `json:"best"`
+	BestScore float64        `json:"bestScore"` // Evaluation score of Best against the baseline
+	History   []Step         `json:"history"`
+}
+
+// LoadCheckpoint reads the checkpoint of a run of cfg, returning nil if the file doesn't exist
+// A checkpoint saved by a run with another seed, start or baseline is refused:
+// resuming it would mix two different runs.
+func LoadCheckpoint(path string, cfg Config) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var c Checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	if c.Version != CheckpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version %d (expected %d)", c.Version, CheckpointVersion)
+	}
+	switch {
+	case c.Seed != cfg.Seed:
+		return nil, fmt.Errorf("%s was saved by a run with seed %d, not %d", path, c.Seed, cfg.Seed)
+	case !sameWeights(c.Start, cfg.Start):
+		return nil, fmt.Errorf("%s was saved by a run with other starting weights", path)
+	case !sameWeights(c.Baseline, cfg.Baseline):
+		return nil, fmt.Errorf("%s was saved by a run against another baseline", path)
+	}
+	return &c, nil
+}
+
+// sameWeights reports whether two weight sets have the same values, whatever their names
+func sameWeights(a, b ai.EvalWeights) bool {
+	a.Name, b.Name = "", ""
+	return a == b
+}
+
+// Save writes the checkpoint, replacing the file in one step
+func (c *Checkpoint) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Run tunes the weights with SPSA (simultaneous perturbation stochastic approximation)
+//
+// Every iteration perturbs all weights at once in a random direction, scores
+// both perturbed sets by playing the baseline on the same seeded deals, and
+// moves the weights along the estimated gradient. Weights are tuned relative
+// to their starting size and kept non-negative. Every EvalEvery iterations
+// the current weights are scored on a larger set of deals; the best ones are
+// kept in the checkpoint.
+//
+// Run resumes from resume if it isn't nil, saves the checkpoint to
+// checkpointPath (if set) after every iteration, and returns it at the end.
+// If ctx is cancelled, Run returns the progress so far with ctx's error.
+func Run(ctx context.Context, cfg Config, resume *Checkpoint, checkpointPath string, progress func(Step)) (*Checkpoint, error) {
+	if cfg.Players == 0 {
+		cfg.Players = 2
+	}
+	if cfg.Games < 2 || cfg.EvalGames < 2 {
+		return nil, fmt.Errorf("tuning needs at least 2 games per step and evaluation")
+	}
+	if cfg.Stability <= 0 {
+		cfg.Stability = float64(cfg.Iterations) / 10
+	}
+	if cfg.EvalEvery <= 0 {
+		cfg.EvalEvery = cfg.Iterations
+	}
+
+	cp := resume
+	if cp == nil {
+		score, err := evaluate(ctx, cfg, cfg.Start, cfg.Seed+evalSeedOffset, cfg.EvalGames)
+		if err != nil {
+			return nil, err
+		}
+		cp = &Checkpoint{
+			Version:   CheckpointVersion,
+			Seed:      cfg.Seed,
+			Start:     cfg.Start,
+			Baseline:  cfg.Baseline,
+			Scale:     scales(cfg.Start),
+			Current:   cfg.Start,
+			Best:      cfg.Start,
+			BestScore: score,
+		}
+	}
+
+	scale := cp.Scale
+	if len(scale) != len(params(&cp.Current)) {
+		return nil, fmt.Errorf("checkpoint has %d weights, expected %d", len(scale), len(params(&cp.Current)))
+	}
+	theta := toVector(cp.Current, scale)
+
+	for k := cp.Iteration; k < cfg.Iterations; k++ {
+		ak := cfg.StepSize / math.Pow(float64(k+1)+cfg.Stability, 0.602)
+		ck := cfg.Perturb / math.Pow(float64(k+1), 0.101)
+
+		// Perturb every weight by ±ck, keeping both sides non-negative
+		rng := rand.New(rand.NewSource(cfg.Seed + int64(k)))
+		delta := make([]float64, len(theta))
+		plus := make([]float64, len(theta))
+		minus := make([]float64, len(theta))
+		for i := range theta {
+			delta[i] = float64(rng.Intn(2)*2 - 1)
+			plus[i] = max(theta[i]+ck*delta[i], 0)
+			minus[i] = max(theta[i]-ck*delta[i], 0)
+		}
+
+		// Score both sides on the same deals
+		seed := cfg.Seed + int64(k)*int64(cfg.Games)
+		fPlus, err := evaluate(ctx, cfg, fromVector(cp.Current, plus, scale), seed, cfg.Games)
+		if err != nil {
+			return cp, err
+		}
+		fMinus, err := evaluate(ctx, cfg, fromVector(cp.Current, minus, scale), seed, cfg.Games)
+		if err != nil {
+			return cp, err
+		}
+
+		// Gradient ascent step, over the distance actually between the two
+		// sides: less than 2ck where a weight near 0 was clamped
+		for i := range theta {
+			gradient := (fPlus - fMinus) / (plus[i] - minus[i])
+			theta[i] = max(theta[i]+ak*gradient, 0)
+		}
+		cp.Current = fromVector(cp.Current, theta, scale)
+		cp.Iteration = k + 1
+
+		step := Step{Iteration: k + 1, Plus: fPlus, Minus: fMinus}
+		if cp.Iteration%cfg.EvalEvery == 0 || cp.Iteration == cfg.Iterations {
+			score, err := evaluate(ctx, cfg, cp.Current, cfg.Seed+evalSeedOffset, cfg.EvalGames)
+			if err != nil {
+				return cp, err
+			}
+			step.Eval, step.Evaluated = score, true
+			if score > cp.BestScore {
+				cp.Best, cp.BestScore = cp.Current, score
+			}
+		}
+		cp.History = append(cp.History, step)
+
+		if checkpointPath != "" {
+			if err := cp.Save(checkpointPath); err != nil {
+				return cp, err
+			}
+		}
+		if progress != nil {
+			progress(step)
+		}
+	}
+
+	return cp, nil
+}
+
+// evaluate plays weights against the baseline and returns the candidate's mean reward in [0, 1]
+// Rewards are ai.OutcomeReward, whose margin term smooths the objective.
+func evaluate(ctx context.Context, cfg Config, weights ai.EvalWeights, seed int64, games int) (float64, error) {
+	tt := ai.WithTranspositionTable(cfg.TTSize)
+	entrants := []tournament.Entrant{tournament.AIEntrant("candidate", cfg.Difficulty, tt, ai.WithWeights(weights))}
+	for len(entrants) < cfg.Players {
+		entrants = append(entrants, tournament.AIEntrant("baseline", cfg.Difficulty, tt, ai.WithWeights(cfg.Baseline)))
+	}
+
+	results, err := tournament.Run(ctx, tournament.Config{
+		Entrants: entrants,
+		Games:    games,
+		Seed:     seed,
+		Workers:  cfg.Workers,
+		Variant:  cfg.Variant,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	total, counted := 0.0, 0
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		for seat, entrant := range r.Seats {
+			if entrant == 0 {
+				total += ai.OutcomeReward(r.Scores, r.Rows, r.Winner, seat)
+				counted++
+			}
+		}
+	}
+	if counted == 0 {
+		return 0, fmt.Errorf("no games finished")
+	}
+	return total / float64(counted), nil
+}
+
+// params returns pointers to the tunable (float64) weights
+func params(w *ai.EvalWeights) []*float64 {
+	v := reflect.ValueOf(w).Elem()
+	var ps []*float64
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Kind() == reflect.Float64 {
+			ps = append(ps, f.Addr().Interface().(*float64))
+		}
+	}
+	return ps
+}
+
+// ParamNames returns the JSON names of the tuned weights, in vector order
+func ParamNames() []string {
+	t := reflect.TypeOf(ai.EvalWeights{})
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Type.Kind() == reflect.Float64 {
+			names = append(names, f.Tag.Get("json"))
+		}
+	}
+	return names
+}
+
+// scales returns the unit each weight is tuned in: its starting size, at least 1
+func scales(w ai.EvalWeights) []float64 {
+	ps := params(&w)
+	s := make([]float64, len(ps))
+	for i, p := range ps {
+		s[i] = max(math.Abs(*p), 1)
+	}
+	return s
+}
+
+// toVector converts weights to the tuning vector
+func toVector(w ai.EvalWeights, scale []float64) []float64 {
+	ps := params(&w)
+	v := make([]float64, len(ps))
+	for i, p := range ps {
+		v[i] = *p / scale[i]
+	}
+	return v
+}
+
+// fromVector returns base with its tunable weights set from a tuning vector
+func fromVector(base ai.EvalWeights, v []float64, scale []float64) ai.EvalWeights {
+	w := base
+	for i, p := range params(&w) {
+		*p = v[i] * scale[i]
+	}
+	return w
+}